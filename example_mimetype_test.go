@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/gabriel-vasile/mimetype"
+	"github.com/gabriel-vasile/mimetype/signature"
 )
 
 func Example_detect() {
@@ -71,3 +72,19 @@ func Example_extend() {
 	fmt.Println(mtype.String(), mtype.Extension())
 	// Output: text/foobar .fb
 }
+
+// The signature package has helpers for writing detectors which are safe to
+// use on truncated inputs.
+func Example_extendSignature() {
+	// Truevision TGA images have no header magic, but version 2 files end with the
+	// TRUEVISION-XFILE footer.
+	tgaDetector := func(raw []byte, limit uint32) bool {
+		return signature.MagicFooter(raw, limit, []byte("TRUEVISION-XFILE.\x00"))
+	}
+
+	mimetype.Extend(tgaDetector, "image/x-tga", ".tga")
+	mtype := mimetype.Detect([]byte("\x00\x00\x02...TRUEVISION-XFILE.\x00"))
+
+	fmt.Println(mtype.String(), mtype.Extension())
+	// Output: image/x-tga .tga
+}
@@ -3,6 +3,8 @@ package magic
 import (
 	"bytes"
 	"encoding/binary"
+	"hash/crc32"
+	"strconv"
 )
 
 var (
@@ -18,8 +20,6 @@ var (
 	})
 	// Xar matches an eXtensible ARchive format file.
 	Xar = prefix([]byte{0x78, 0x61, 0x72, 0x21})
-	// Bz2 matches a bzip2 file.
-	Bz2 = prefix([]byte{0x42, 0x5A, 0x68})
 	// Ar matches an ar (Unix) archive file.
 	Ar = prefix([]byte{0x21, 0x3C, 0x61, 0x72, 0x63, 0x68, 0x3E})
 	// Deb matches a Debian package file.
@@ -31,8 +31,6 @@ var (
 	Warc = prefix([]byte("WARC/1.0"), []byte("WARC/1.1"))
 	// Cab matches a Microsoft Cabinet archive file.
 	Cab = prefix([]byte("MSCF\x00\x00\x00\x00"))
-	// Xz matches an xz compressed stream based on https://tukaani.org/xz/xz-file-format.txt.
-	Xz = prefix([]byte{0xFD, 0x37, 0x7A, 0x58, 0x5A, 0x00})
 	// Lzip matches an Lzip compressed file.
 	Lzip = prefix([]byte{0x4c, 0x5a, 0x49, 0x50})
 	// RPM matches an RPM or Delta RPM package file.
@@ -63,6 +61,72 @@ func Zstd(raw []byte, limit uint32) bool {
 		(sig >= 0x184D2A50 && sig <= 0x184D2A5F)
 }
 
+// ZstdParams reads the frame header of the first Zstandard frame, after any
+// skippable frames, and returns the decompressed content size and the
+// dictionary ID when the frame header has them.
+func ZstdParams(raw []byte, _ uint32) map[string]string {
+	for i := 0; i < 4 && len(raw) >= 8; i++ {
+		sig := binary.LittleEndian.Uint32(raw)
+		if sig >= 0x184D2A50 && sig <= 0x184D2A5F {
+			size := binary.LittleEndian.Uint32(raw[4:8])
+			if uint64(size) > uint64(len(raw)-8) {
+				return nil
+			}
+			raw = raw[8+size:]
+			continue
+		}
+		if sig != 0xFD2FB528 {
+			return nil
+		}
+		break
+	}
+	if len(raw) < 5 || binary.LittleEndian.Uint32(raw) != 0xFD2FB528 {
+		return nil
+	}
+
+	desc := raw[4]
+	singleSegment := desc&0x20 != 0
+	didSize := [4]int{0, 1, 2, 4}[desc&0x03]
+	fcsSize := [4]int{0, 2, 4, 8}[desc>>6]
+	if fcsSize == 0 && singleSegment {
+		fcsSize = 1
+	}
+	h := raw[5:]
+	// The window descriptor is absent for single segment frames.
+	if !singleSegment {
+		if len(h) == 0 {
+			return nil
+		}
+		h = h[1:]
+	}
+	if len(h) < didSize+fcsSize {
+		return nil
+	}
+
+	ps := map[string]string{}
+	if did := leUint(h[:didSize]); did != 0 {
+		ps["dictionary-id"] = strconv.FormatUint(did, 10)
+	}
+	if fcsSize > 0 {
+		fcs := leUint(h[didSize : didSize+fcsSize])
+		// The 2 bytes form has an offset of 256.
+		if fcsSize == 2 {
+			fcs += 256
+		}
+		ps["content-size"] = strconv.FormatUint(fcs, 10)
+	}
+	return ps
+}
+
+// leUint decodes a little-endian unsigned integer of up to 8 bytes.
+func leUint(b []byte) uint64 {
+	var v uint64
+	for i := len(b) - 1; i >= 0; i-- {
+		v = v<<8 | uint64(b[i])
+	}
+	return v
+}
+
 // CRX matches a Chrome extension file: a zip archive prepended by a package header.
 func CRX(raw []byte, limit uint32) bool {
 	const minHeaderLen = 16
@@ -161,3 +225,146 @@ func tarChksum(b []byte) (unsigned, signed int64) {
 	}
 	return unsigned, signed
 }
+
+// HoudiniHip matches a SideFX Houdini scene. The scene is stored as an old
+// ASCII (odc) cpio archive, whose first entry is named ".start".
+func HoudiniHip(raw []byte, _ uint32) bool {
+	// The 76 bytes odc header ends with the name size and the file size.
+	return len(raw) > 83 &&
+		bytes.HasPrefix(raw, []byte("070707")) &&
+		bytes.Equal(raw[59:65], []byte("000007")) &&
+		bytes.Equal(raw[76:83], []byte(".start\x00"))
+}
+
+// Bz2 matches a bzip2 file. The "BZh" magic and the block size digit are
+// followed by the magic of the first block, or by the end of stream magic for
+// empty streams.
+func Bz2(raw []byte, _ uint32) bool {
+	return bz2Stream(raw)
+}
+
+func bz2Stream(raw []byte) bool {
+	if len(raw) < 10 || !bytes.HasPrefix(raw, []byte("BZh")) || raw[3] < '1' || raw[3] > '9' {
+		return false
+	}
+	return bytes.HasPrefix(raw[4:], []byte{0x31, 0x41, 0x59, 0x26, 0x53, 0x59}) ||
+		bytes.HasPrefix(raw[4:], []byte{0x17, 0x72, 0x45, 0x38, 0x50, 0x90})
+}
+
+// Bz2Params tells whether a bzip2 file is made of several concatenated streams,
+// as written by parallel compressors like pbzip2. Each stream is padded to a
+// byte boundary at its end, but the blocks inside a stream are not byte aligned
+// and their sizes are not stored, so the end of a stream cannot be found
+// without decompressing it. Instead, the header of another stream is looked
+// for at every offset.
+func Bz2Params(raw []byte, _ uint32) map[string]string {
+	for o := 4; ; {
+		i := bytes.Index(raw[o:], []byte("BZh"))
+		if i == -1 {
+			break
+		}
+		o += i
+		if bz2Stream(raw[o:]) {
+			return map[string]string{"multistream": "true"}
+		}
+		o++
+	}
+	return nil
+}
+
+// Xz matches an xz compressed stream based on https://tukaani.org/xz/xz-file-format.txt.
+// The 12 bytes stream header is the magic, the stream flags and the CRC32 of
+// the stream flags. When the whole file is provided, the stream footer at the
+// end of the file must have the same stream flags and the footer magic.
+func Xz(raw []byte, limit uint32) bool {
+	if len(raw) < 12 || !bytes.HasPrefix(raw, []byte{0xFD, 0x37, 0x7A, 0x58, 0x5A, 0x00}) {
+		return false
+	}
+	flags := raw[6:8]
+	if flags[0] != 0 || flags[1]&0xF0 != 0 ||
+		crc32.ChecksumIEEE(flags) != binary.LittleEndian.Uint32(raw[8:12]) {
+		return false
+	}
+	if limit != 0 && uint32(len(raw)) >= limit {
+		return true
+	}
+
+	// Streams can be followed by null padding in multiples of 4 bytes.
+	end := len(raw)
+	for end >= 4 && bytes.Equal(raw[end-4:end], []byte{0, 0, 0, 0}) {
+		end -= 4
+	}
+	// The smallest stream is a header, an empty index and a footer.
+	if end < 32 {
+		return false
+	}
+	footer := raw[end-12 : end]
+	return bytes.Equal(footer[8:10], flags) && bytes.Equal(footer[10:], []byte("YZ"))
+}
+
+// XzParams returns the integrity check type of an xz stream.
+func XzParams(raw []byte, _ uint32) map[string]string {
+	if len(raw) < 8 {
+		return nil
+	}
+	checks := map[byte]string{0x00: "none", 0x01: "crc32", 0x04: "crc64", 0x0A: "sha256"}
+	if c, ok := checks[raw[7]]; ok {
+		return map[string]string{"check": c}
+	}
+	return nil
+}
+
+// Pbzx matches a pbzx stream, used by Apple for OTA updates and for the
+// Payload of macOS installer packages. The magic is followed by the big-endian
+// maximum chunk size, then by chunks made of their uncompressed size, their
+// stored size and their data. Chunks are xz streams, or stored as is when xz
+// compression does not make them smaller.
+func Pbzx(raw []byte, _ uint32) bool {
+	if len(raw) < 12 || !bytes.HasPrefix(raw, []byte("pbzx")) {
+		return false
+	}
+	chunkSize := binary.BigEndian.Uint64(raw[4:12])
+	if chunkSize == 0 || chunkSize > 1<<32 {
+		return false
+	}
+	if len(raw) < 28 {
+		return true
+	}
+	size := binary.BigEndian.Uint64(raw[12:20])
+	stored := binary.BigEndian.Uint64(raw[20:28])
+	if size == 0 || size > chunkSize || stored == 0 || stored > size {
+		return false
+	}
+	if stored < size && len(raw) >= 34 {
+		return bytes.HasPrefix(raw[28:], []byte{0xFD, 0x37, 0x7A, 0x58, 0x5A, 0x00})
+	}
+	return true
+}
+
+// Asar matches an Electron archive. The archive starts with two Chromium
+// pickles: the first only holds the size of the second, which holds the
+// header, a JSON object describing the tree of files under a "files" key.
+// All the values are little-endian uint32.
+// https://github.com/electron/asar
+func Asar(raw []byte, _ uint32) bool {
+	if len(raw) < 16 || binary.LittleEndian.Uint32(raw) != 4 {
+		return false
+	}
+	size := binary.LittleEndian.Uint32(raw[4:])
+	payload := binary.LittleEndian.Uint32(raw[8:])
+	l := binary.LittleEndian.Uint32(raw[12:])
+	// The payload of the header pickle is the JSON string, made of its length
+	// and its bytes padded to a multiple of 4.
+	if size < 8 || payload != size-4 || l > payload-4 || payload-4-l >= 4 {
+		return false
+	}
+	header := raw[16:]
+	if uint32(len(header)) > l {
+		header = header[:l]
+	}
+	if len(header) == 0 || header[0] != '{' {
+		return false
+	}
+	v := jsonValue(header, []byte(`"files"`))
+	return len(v) > 0 && v[0] == '{'
+}
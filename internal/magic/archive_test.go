@@ -28,3 +28,11 @@ func TestTarParseOctal(t *testing.T) {
 		}
 	}
 }
+
+func TestXzParamsShortInput(t *testing.T) {
+	for i := 0; i < 8; i++ {
+		if ps := XzParams([]byte("\xFD7zXZ\x00\x00\x04")[:i], 0); ps != nil {
+			t.Errorf("%d bytes: expected no params, got: %v", i, ps)
+		}
+	}
+}
@@ -55,10 +55,66 @@ func Mp3(raw []byte, limit uint32) bool {
 }
 
 // Wav matches a Waveform Audio File Format file.
+// RF64 and BW64 are WAV files with a ds64 chunk holding 64 bits sizes; they only
+// differ from RIFF WAV files by the ID of the first chunk.
 func Wav(raw []byte, limit uint32) bool {
-	return len(raw) > 12 &&
-		bytes.Equal(raw[:4], []byte("RIFF")) &&
-		bytes.Equal(raw[8:12], []byte{0x57, 0x41, 0x56, 0x45})
+	if len(raw) <= 12 || !bytes.Equal(raw[8:12], []byte{0x57, 0x41, 0x56, 0x45}) {
+		return false
+	}
+	switch string(raw[:4]) {
+	case "RIFF", "RF64", "BW64":
+		return true
+	}
+	return false
+}
+
+// WavParams reports the rf64 or bw64 variant of WAV files with 64 bits sizes,
+// and broadcast=true for Broadcast Wave Format files, that is, files having a
+// bext chunk before the data chunk. The origination date of the bext chunk is
+// reported too.
+// https://tech.ebu.ch/docs/tech/tech3285.pdf
+// https://tech.ebu.ch/docs/tech/tech3306v1_1.pdf
+func WavParams(raw []byte, _ uint32) map[string]string {
+	ps := map[string]string{}
+	switch string(raw[:4]) {
+	case "RF64":
+		ps["variant"] = "rf64"
+	case "BW64":
+		ps["variant"] = "bw64"
+	}
+	// Stop after a few chunks if the data chunk was not found.
+	for i, o := 0, 12; i < 16 && len(raw) >= o+8; i++ {
+		size := int(binary.LittleEndian.Uint32(raw[o+4 : o+8]))
+		switch string(raw[o : o+4]) {
+		case "bext":
+			ps["broadcast"] = "true"
+			// Description, Originator and OriginatorReference come before
+			// the yyyy-mm-dd OriginationDate.
+			if d := raw[o+8:]; len(d) >= 330 && isBextDate(d[320:330]) {
+				ps["origination-date"] = string(d[320:330])
+			}
+			return ps
+		case "data":
+			return ps
+		}
+		// Chunks are padded to an even size.
+		o += 8 + size + size&1
+		if o < 0 {
+			break
+		}
+	}
+	return ps
+}
+
+// isBextDate checks b is a date like 2024-05-17. The EBU specification allows
+// any separator, but the digits are required.
+func isBextDate(b []byte) bool {
+	for i, c := range b {
+		if i != 4 && i != 7 && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
 }
 
 // Aiff matches Audio Interchange File Format file.
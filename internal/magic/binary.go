@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"debug/macho"
 	"encoding/binary"
+	"strconv"
+	"unicode/utf8"
 )
 
 var (
@@ -201,3 +203,418 @@ func TzIf(raw []byte, limit uint32) bool {
 	// Version has to be NUL (0x00), '2' (0x32) or '3' (0x33).
 	return raw[4] == 0x00 || raw[4] == 0x32 || raw[4] == 0x33
 }
+
+// Exi matches an Efficient XML Interchange stream starting with the optional
+// "$EXI" cookie. The cookie is followed by the EXI header, whose first two
+// bits are the distinguishing bits 10.
+// https://www.w3.org/TR/exi/#header
+func Exi(raw []byte, limit uint32) bool {
+	return len(raw) > 4 &&
+		bytes.HasPrefix(raw, []byte("$EXI")) &&
+		raw[4]&0xC0 == 0x80
+}
+
+// ExiNoCookie matches an Efficient XML Interchange stream without the "$EXI"
+// cookie. Its header is the single byte 0x80: the distinguishing bits 10, no
+// options and the final version 1. Because a single byte is a weak signature,
+// the first event of the body is decoded too, using the default options: the
+// start of the root element, whose qualified name must be made of name
+// characters. Streams with options in their header are only matched with the
+// cookie, because the options are encoded with their own schema informed grammar.
+// https://www.w3.org/TR/exi/#header
+func ExiNoCookie(raw []byte, limit uint32) bool {
+	if len(raw) < 2 || raw[0] != 0x80 {
+		return false
+	}
+	r := &exiBits{b: raw[1:]}
+	// The URI of the root element is an index in the initial URI table: 1 for
+	// no namespace, 2 for the XML namespace, 3 for the XML Schema instance
+	// namespace, and 0 for a new URI, followed by its characters.
+	uri := r.bits(2)
+	if r.err || uri == 0 && !r.chars(r.uint(), exiURIChar) {
+		return false
+	}
+	// The local name is either 0, followed by an index in the local names
+	// known for the URI (xml:lang, xsi:type, etc.), or its length plus one,
+	// followed by its characters.
+	switch n := r.uint(); {
+	case r.err:
+		return false
+	case n == 0:
+		switch uri {
+		case 2:
+			r.bits(2)
+		case 3:
+			r.bits(1)
+		default:
+			return false
+		}
+		return !r.err
+	default:
+		return r.chars(n-1, exiNameChar)
+	}
+}
+
+// exiBits reads a bit-packed EXI stream, most significant bit first.
+type exiBits struct {
+	b   []byte
+	pos int
+	err bool
+}
+
+func (r *exiBits) bits(n int) uint32 {
+	var v uint32
+	for i := 0; i < n; i++ {
+		if r.pos >= len(r.b)*8 {
+			r.err = true
+			return 0
+		}
+		v = v<<1 | uint32(r.b[r.pos/8]>>(7-r.pos%8)&1)
+		r.pos++
+	}
+	return v
+}
+
+// uint reads an unsigned integer, stored as octets of 7 bits, with the high
+// bit set on all octets but the last.
+func (r *exiBits) uint() uint32 {
+	var v uint32
+	for i := 0; i < 5; i++ {
+		o := r.bits(8)
+		v |= (o & 0x7F) << (7 * i)
+		if o&0x80 == 0 {
+			return v
+		}
+	}
+	r.err = true
+	return 0
+}
+
+// chars reads n characters and reports whether all of them are valid.
+// Names longer than 256 characters are not expected in a header.
+func (r *exiBits) chars(n uint32, valid func(uint32) bool) bool {
+	if r.err || n == 0 || n > 256 {
+		return false
+	}
+	for ; n > 0; n-- {
+		if c := r.uint(); r.err || !valid(c) {
+			return false
+		}
+	}
+	return true
+}
+
+func exiURIChar(c uint32) bool {
+	return c > ' ' && c < 0x7F
+}
+
+func exiNameChar(c uint32) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '_' || c == '-' || c == '.' || c >= 0xC0 && c < 0xD800
+}
+
+// FastInfoset matches a Fast Infoset binary XML document.
+// The document starts with the E0 00 00 01 identification and version bytes,
+// optionally preceded by an XML declaration. The next byte holds the
+// additional data, initial vocabulary, notations, unparsed entities,
+// character encoding scheme, standalone and version presence bits, all
+// prefixed by a padding bit which must be 0.
+// https://www.itu.int/rec/T-REC-X.891-200505-I/en
+func FastInfoset(raw []byte, limit uint32) bool {
+	if bytes.HasPrefix(raw, []byte("<?xml encoding='finf'")) {
+		if i := bytes.Index(raw, []byte("?>")); i != -1 {
+			raw = raw[i+2:]
+		}
+	}
+	return len(raw) > 4 &&
+		bytes.HasPrefix(raw, []byte{0xE0, 0x00, 0x00, 0x01}) &&
+		raw[4]&0x80 == 0
+}
+
+// FidoAttestation matches a WebAuthn attestation object, which is a CBOR map
+// with the "fmt", "attStmt" and "authData" keys, optionally preceded by the
+// CBOR self-described tag.
+// https://www.w3.org/TR/webauthn-2/#sctn-attestation
+func FidoAttestation(raw []byte, limit uint32) bool {
+	raw = bytes.TrimPrefix(raw, []byte{0xD9, 0xD9, 0xF7})
+	major, n, raw, ok := cborHead(raw)
+	// Attestation objects have the three keys, but allow a few extensions.
+	if !ok || major != 5 || n < 3 || n > 8 {
+		return false
+	}
+
+	const keyFmt, keyAttStmt, keyAuthData = 1, 2, 4
+	seen := 0
+	for i := uint64(0); i < n; i++ {
+		major, l, rest, ok := cborHead(raw)
+		if !ok || major != 3 || l > uint64(len(rest)) {
+			return false
+		}
+		key := string(rest[:l])
+		raw = rest[l:]
+		valMajor, _, _, ok := cborHead(raw)
+		if !ok {
+			return false
+		}
+		switch {
+		case key == "fmt" && valMajor == 3:
+			seen |= keyFmt
+		case key == "attStmt" && valMajor == 5:
+			seen |= keyAttStmt
+		case key == "authData" && valMajor == 2:
+			seen |= keyAuthData
+		case key == "fmt", key == "attStmt", key == "authData":
+			return false
+		}
+		if seen == keyFmt|keyAttStmt|keyAuthData {
+			return true
+		}
+		if raw, ok = cborSkip(raw, 0); !ok {
+			return false
+		}
+	}
+
+	return false
+}
+
+// cborHead decodes the head of a CBOR data item. It returns the major type,
+// the argument and the bytes following the head. Indefinite lengths are not
+// supported.
+func cborHead(raw []byte) (major byte, arg uint64, rest []byte, ok bool) {
+	if len(raw) == 0 {
+		return 0, 0, nil, false
+	}
+	major, info := raw[0]>>5, raw[0]&0x1F
+	raw = raw[1:]
+	switch {
+	case info < 24:
+		return major, uint64(info), raw, true
+	case info == 24 && len(raw) >= 1:
+		return major, uint64(raw[0]), raw[1:], true
+	case info == 25 && len(raw) >= 2:
+		return major, uint64(binary.BigEndian.Uint16(raw)), raw[2:], true
+	case info == 26 && len(raw) >= 4:
+		return major, uint64(binary.BigEndian.Uint32(raw)), raw[4:], true
+	case info == 27 && len(raw) >= 8:
+		return major, binary.BigEndian.Uint64(raw), raw[8:], true
+	}
+	return 0, 0, nil, false
+}
+
+// cborSkip returns the bytes following the CBOR data item at the start of raw.
+// Nesting is limited so that crafted inputs cannot make the check expensive.
+func cborSkip(raw []byte, depth int) ([]byte, bool) {
+	if depth > 8 {
+		return nil, false
+	}
+	major, arg, raw, ok := cborHead(raw)
+	if !ok {
+		return nil, false
+	}
+	switch major {
+	case 2, 3: // Byte and text strings.
+		if arg > uint64(len(raw)) {
+			return nil, false
+		}
+		return raw[arg:], true
+	case 4, 5: // Arrays and maps.
+		// Each item needs at least one byte.
+		if arg > uint64(len(raw)) {
+			return nil, false
+		}
+		if major == 5 {
+			arg *= 2
+		}
+		for i := uint64(0); i < arg; i++ {
+			if raw, ok = cborSkip(raw, depth+1); !ok {
+				return nil, false
+			}
+		}
+		return raw, true
+	case 6: // Tags.
+		return cborSkip(raw, depth+1)
+	}
+	// Integers, simple values and floats have no content after the head.
+	return raw, true
+}
+
+// ConfluentWire matches a record serialized with the Confluent Schema Registry
+// wire format: a 0x00 magic byte, a 4 bytes big-endian schema ID and the
+// serialized body.
+// A single zero byte is a weak signature, so the schema ID must be a plausible
+// registry ID (non zero and below 2^24) and the body must look like a JSON
+// document or like an Avro record starting with a string field.
+// https://docs.confluent.io/platform/current/schema-registry/fundamentals/serdes-develop/index.html#wire-format
+func ConfluentWire(raw []byte, limit uint32) bool {
+	if len(raw) < 7 || raw[0] != 0x00 || raw[1] != 0x00 {
+		return false
+	}
+	if binary.BigEndian.Uint32(raw[1:5]) == 0 {
+		return false
+	}
+	body := raw[5:]
+	if body[0] == '{' || body[0] == '[' {
+		if limit > 5 {
+			limit -= 5
+		}
+		return JSON(body, limit)
+	}
+
+	// Avro strings are a zigzag varint length followed by UTF-8 bytes.
+	l, n := binary.Uvarint(body)
+	if n <= 0 || l&1 == 1 || l/2 < 2 {
+		return false
+	}
+	str := body[n:]
+	if l/2 < uint64(len(str)) {
+		str = str[:l/2]
+	} else if limit == 0 || uint32(len(raw)) < limit {
+		// The whole input was provided, but the string is cut.
+		return false
+	}
+	for _, c := range str {
+		if c < 0x20 || c == 0x7F {
+			return false
+		}
+	}
+	return utf8.Valid(str)
+}
+
+// ConfluentWireParams returns the schema ID of a Confluent wire format record.
+func ConfluentWireParams(raw []byte, _ uint32) map[string]string {
+	if len(raw) < 5 {
+		return nil
+	}
+	return map[string]string{
+		"schema-id": strconv.FormatUint(uint64(binary.BigEndian.Uint32(raw[1:5])), 10),
+	}
+}
+
+// MayaBinary matches an Autodesk Maya binary scene, an IFF container with the
+// Maya form type. Maya writes the group as FOR4, with 4 bytes sizes, or as
+// FOR8, with 8 bytes sizes; older scenes use the standard FORM group.
+func MayaBinary(raw []byte, _ uint32) bool {
+	return iffForm(raw, []byte("Maya"))
+}
+
+// iffForm checks raw is an IFF FORM group of the form type typ.
+func iffForm(raw, typ []byte) bool {
+	if len(raw) < 16 {
+		return false
+	}
+	switch string(raw[:4]) {
+	case "FORM", "FOR4":
+		return bytes.Equal(raw[8:12], typ)
+	case "FOR8":
+		return bytes.Equal(raw[12:16], typ)
+	}
+	return false
+}
+
+// PgsSup matches a Presentation Graphic Stream, the Blu-ray subtitle format.
+// The stream is a sequence of segments with a 13 bytes header: the "PG" magic,
+// two timestamps, the segment type and the segment size. Because "PG" is a weak
+// magic, the type of every segment found in the input is checked, and so is
+// the magic of the segment following the first one.
+// https://blog.thescorpius.com/index.php/2017/07/15/presentation-graphic-stream-sup-files-bluray-subtitle-format/
+func PgsSup(raw []byte, limit uint32) bool {
+	// Leftovers shorter than a segment are fine only if the input was cut.
+	cut := limit > 0 && uint32(len(raw)) >= limit
+	segments := 0
+	for len(raw) >= 13 && segments < 8 {
+		if raw[0] != 'P' || raw[1] != 'G' {
+			return false
+		}
+		switch raw[10] {
+		case 0x14, 0x15, 0x16, 0x17, 0x80:
+		default:
+			return false
+		}
+		segments++
+		size := 13 + int(binary.BigEndian.Uint16(raw[11:13]))
+		if size > len(raw) {
+			return cut
+		}
+		raw = raw[size:]
+	}
+
+	return segments > 0 && (segments == 8 || len(raw) == 0 || cut)
+}
+
+// BomStore matches an Apple Bill Of Materials file, used for installer
+// receipts and for compiled asset catalogs (Assets.car). The magic is followed
+// by the big-endian format version, which is 1.
+// Compiled storyboards are directories, so they cannot be detected from content.
+func BomStore(raw []byte, _ uint32) bool {
+	return len(raw) >= 12 &&
+		bytes.HasPrefix(raw, []byte("BOMStore")) &&
+		binary.BigEndian.Uint32(raw[8:12]) == 1
+}
+
+// NodeSea matches a Node.js single executable application, a Node.js binary
+// with a blob holding the application injected in a NODE_SEA_BLOB resource.
+// Every Node.js binary has the NODE_SEA_BLOB name and the injection fuse in
+// its code, but only the injection flips the fuse to 1 and adds the blob
+// resource, which are both required.
+// The fuse is in the read-only data of the binary, usually megabytes away
+// from its start, so detection needs a big read limit on the executable
+// formats and, past the first 1MB, a heuristic scan limit covering the fuse.
+// V8 code cache files have a header specific to the V8 version which wrote
+// them, and are not detected.
+// https://nodejs.org/api/single-executable-applications.html
+func NodeSea(raw []byte, _ uint32) bool {
+	return bytes.Contains(raw, []byte("NODE_SEA_FUSE_fce680ab2cc467b6e072b8b5df1996b2:1")) &&
+		nodeSeaBlob(raw)
+}
+
+// nodeSeaBlob reports whether raw has the NODE_SEA_BLOB resource added by the
+// injection: a PE resource, a section of the NODE_SEA Mach-O segment or an ELF
+// note. The plain name is in every Node.js binary, so the structure around it
+// is checked too.
+func nodeSeaBlob(raw []byte) bool {
+	// PE resource names are UTF-16 strings prefixed by their length.
+	if bytes.Contains(raw, []byte("\x0d\x00N\x00O\x00D\x00E\x00_\x00S\x00E\x00A\x00_\x00B\x00L\x00O\x00B\x00")) {
+		return true
+	}
+	// A Mach-O section header starts with the section and segment names.
+	if bytes.Contains(raw, []byte("__NODE_SEA_BLOB\x00NODE_SEA\x00")) {
+		return true
+	}
+	// An ELF note has the size of its name, the size of its descriptor and its
+	// type, followed by the name.
+	name := []byte("NODE_SEA_BLOB\x00")
+	for o := 0; ; {
+		i := bytes.Index(raw[o:], name)
+		if i == -1 {
+			return false
+		}
+		i += o
+		if i >= 12 && binary.LittleEndian.Uint32(raw[i-12:]) == uint32(len(name)) {
+			return true
+		}
+		o = i + 1
+	}
+}
+
+// DenoCompiled matches an executable built with deno compile. The JavaScript
+// bundle and its metadata are appended to the Deno runtime, and the file ends
+// with a 24 bytes trailer: the "d3n0l4nd" magic followed by the big-endian
+// offsets of the bundle and of the metadata.
+// The trailer is only read when the whole file was provided. Binaries are
+// bigger than any usual read limit, so with the default settings a real Deno
+// program is never matched; the executable formats need a read limit of 0,
+// e.g. mimetype.Lookup("application/x-elf").SetReadLimit(0).
+// Newer Deno versions store the bundle in a section of the executable instead,
+// and are not detected.
+func DenoCompiled(raw []byte, limit uint32) bool {
+	if limit != 0 && uint32(len(raw)) >= limit || len(raw) < 24 {
+		return false
+	}
+	trailer := raw[len(raw)-24:]
+	if !bytes.HasPrefix(trailer, []byte("d3n0l4nd")) {
+		return false
+	}
+	bundle := binary.BigEndian.Uint64(trailer[8:])
+	metadata := binary.BigEndian.Uint64(trailer[16:])
+	return bundle < metadata && metadata < uint64(len(raw)-24)
+}
@@ -1,6 +1,9 @@
 package magic
 
-import "bytes"
+import (
+	"bytes"
+	"encoding/binary"
+)
 
 var (
 	// Pdf matches a Portable Document Format file.
@@ -17,8 +20,21 @@ var (
 	Fdf = prefix([]byte("%FDF"))
 	// Mobi matches a Mobi file.
 	Mobi = offset([]byte("BOOKMOBI"), 60)
-	// Lit matches a Microsoft Lit file.
-	Lit = prefix([]byte("ITOLITLS"))
+	// PalmDoc matches a PalmDoc book, the Palm database read by AportisDoc.
+	PalmDoc = offset([]byte("TEXtREAd"), 60)
+	// Plucker matches a Plucker document.
+	Plucker = offset([]byte("DataPlkr"), 60)
+	// Indesign matches an Adobe InDesign document. The file starts with the
+	// GUID of the master page, 0606EDF5-D81D-46E5-BD31-EFE7FE74B71D.
+	Indesign = prefix([]byte{0x06, 0x06, 0xED, 0xF5, 0xD8, 0x1D, 0x46, 0xE5,
+		0xBD, 0x31, 0xEF, 0xE7, 0xFE, 0x74, 0xB7, 0x1D})
+	// CupsRaster matches a CUPS raster stream. The sync word is written using
+	// the host byte order and also tells the version of the raster format.
+	CupsRaster = prefix(
+		[]byte("RaSt"), []byte("tSaR"),
+		[]byte("RaS2"), []byte("2SaR"),
+		[]byte("RaS3"), []byte("3SaR"),
+	)
 )
 
 // DjVu matches a DjVu file.
@@ -60,3 +76,111 @@ func P7s(raw []byte, limit uint32) bool {
 
 	return false
 }
+
+// Azw3 matches a Kindle Format 8 book, stored like a Mobi file as a Palm
+// database. The Mobi header in the first record has the file version 8 or,
+// for hybrid books also having an old Mobi part, an EXTH record 121 holding
+// the index of the KF8 boundary record.
+// The first record is after the list of records, so the books with many
+// records need a read limit bigger than the default.
+// https://wiki.mobileread.com/wiki/MOBI
+func Azw3(raw []byte, _ uint32) bool {
+	if len(raw) < 86 {
+		return false
+	}
+	// The offset of the first record is the first entry of the record list.
+	rec := raw[min(int(binary.BigEndian.Uint32(raw[78:82])), len(raw)):]
+	// The PalmDOC header is followed by the Mobi header.
+	if len(rec) < 16+0x74 || !bytes.Equal(rec[16:20], []byte("MOBI")) {
+		return false
+	}
+	mobi := rec[16:]
+	if binary.BigEndian.Uint32(mobi[0x14:]) == 8 {
+		return true
+	}
+	if binary.BigEndian.Uint32(mobi[0x70:])&0x40 == 0 {
+		return false
+	}
+	// The EXTH header follows the Mobi header.
+	exth := mobi[min(int(binary.BigEndian.Uint32(mobi[4:8])), len(mobi)):]
+	if len(exth) < 12 || !bytes.Equal(exth[:4], []byte("EXTH")) {
+		return false
+	}
+	count := binary.BigEndian.Uint32(exth[8:12])
+	recs := exth[12:]
+	for i := uint32(0); i < count && len(recs) >= 8; i++ {
+		typ := binary.BigEndian.Uint32(recs[:4])
+		size := binary.BigEndian.Uint32(recs[4:8])
+		if typ == 121 {
+			return size >= 12 && len(recs) >= 12 &&
+				binary.BigEndian.Uint32(recs[8:12]) != 0xFFFFFFFF
+		}
+		if size < 8 {
+			return false
+		}
+		recs = recs[min(int(size), len(recs)):]
+	}
+	return false
+}
+
+// PalmPdb matches a Palm database file. The 78 bytes header starts with the
+// NUL terminated name of the database and has the type and creator codes at
+// offset 60 and 64. The record list follows, with 8 bytes per record.
+// https://wiki.mobileread.com/wiki/PDB
+func PalmPdb(raw []byte, _ uint32) bool {
+	if len(raw) < 78 {
+		return false
+	}
+	name, _, ok := bytes.Cut(raw[:32], []byte{0})
+	if !ok || len(name) == 0 || !printableASCII(name) || !printableASCII(raw[60:68]) {
+		return false
+	}
+	n := int(binary.BigEndian.Uint16(raw[76:78]))
+	// Records are stored after the record list, in order.
+	prev := uint32(78 + 8*n)
+	for i, o := 0, 78; i < n && len(raw) >= o+8; i, o = i+1, o+8 {
+		off := binary.BigEndian.Uint32(raw[o:])
+		if off < prev {
+			return false
+		}
+		prev = off
+	}
+	return true
+}
+
+// PalmPdbParams returns the type and creator codes of a Palm database.
+func PalmPdbParams(raw []byte, _ uint32) map[string]string {
+	if len(raw) < 68 {
+		return nil
+	}
+	return map[string]string{
+		"type":    string(raw[60:64]),
+		"creator": string(raw[64:68]),
+	}
+}
+
+// EReader matches a Palm Digital Media eReader book. Older books have the
+// "DataPPrs" type and creator.
+func EReader(raw []byte, _ uint32) bool {
+	return len(raw) >= 68 &&
+		(bytes.Equal(raw[60:68], []byte("PNRdPPrs")) || bytes.Equal(raw[60:68], []byte("DataPPrs")))
+}
+
+func printableASCII(b []byte) bool {
+	for _, c := range b {
+		if c < 0x20 || c > 0x7E {
+			return false
+		}
+	}
+	return true
+}
+
+// Lit matches a Microsoft Reader eBook. The "ITOLITLS" signature is followed
+// by the little-endian version, always 1, and the size of the header, 40.
+// http://www.russotto.net/chm/itolitlsformat.html
+func Lit(raw []byte, _ uint32) bool {
+	return len(raw) >= 16 &&
+		bytes.HasPrefix(raw, []byte("ITOLITLS")) &&
+		binary.LittleEndian.Uint32(raw[8:12]) == 1 &&
+		binary.LittleEndian.Uint32(raw[12:16]) == 40
+}
@@ -2,6 +2,8 @@ package magic
 
 import (
 	"bytes"
+	"encoding/binary"
+	"strconv"
 )
 
 var (
@@ -37,3 +39,91 @@ func Ttc(raw []byte, limit uint32) bool {
 		(bytes.Equal(raw[4:8], []byte{0x00, 0x01, 0x00, 0x00}) ||
 			bytes.Equal(raw[4:8], []byte{0x00, 0x02, 0x00, 0x00}))
 }
+
+// Woff2Params tells, for a WOFF2 file wrapping a TrueType Collection, how many
+// fonts the collection has. Single fonts have no parameters. The number of
+// fonts is stored after the table directory, which has entries of variable
+// length.
+// https://www.w3.org/TR/WOFF2/#woff20Header
+func Woff2Params(raw []byte, _ uint32) map[string]string {
+	if len(raw) < 48 {
+		return nil
+	}
+	if !bytes.Equal(raw[4:8], []byte("ttcf")) {
+		return nil
+	}
+	ps := map[string]string{"collection": "true"}
+
+	numTables := int(binary.BigEndian.Uint16(raw[12:14]))
+	b := raw[48:]
+	for i := 0; i < numTables; i++ {
+		if len(b) == 0 {
+			return ps
+		}
+		flags := b[0]
+		b = b[1:]
+		tag := flags & 0x3F
+		// Tag 63 means an arbitrary tag follows; 10 and 11 are glyf and loca.
+		if tag == 63 {
+			if len(b) < 4 {
+				return ps
+			}
+			switch string(b[:4]) {
+			case "glyf":
+				tag = 10
+			case "loca":
+				tag = 11
+			}
+			b = b[4:]
+		}
+		var ok bool
+		// origLength is always present.
+		if b, ok = skipBase128(b); !ok {
+			return ps
+		}
+		// glyf and loca have a transformLength unless the transform is the null
+		// transform, version 3. All other tables only have it for versions 1-3.
+		version := flags >> 6
+		if (tag == 10 || tag == 11) && version != 3 || tag != 10 && tag != 11 && version != 0 {
+			if b, ok = skipBase128(b); !ok {
+				return ps
+			}
+		}
+	}
+
+	// The collection header starts with a 4 bytes version.
+	if len(b) < 5 {
+		return ps
+	}
+	b = b[4:]
+	numFonts := int(b[0])
+	switch b[0] {
+	case 253:
+		if len(b) < 3 {
+			return ps
+		}
+		numFonts = int(binary.BigEndian.Uint16(b[1:3]))
+	case 254:
+		if len(b) < 2 {
+			return ps
+		}
+		numFonts = int(b[1]) + 506
+	case 255:
+		if len(b) < 2 {
+			return ps
+		}
+		numFonts = int(b[1]) + 253
+	}
+	ps["fonts"] = strconv.Itoa(numFonts)
+	return ps
+}
+
+// skipBase128 skips a UIntBase128 number, which is at most 5 bytes long.
+func skipBase128(b []byte) ([]byte, bool) {
+	for i := 0; i < 5 && i < len(b); i++ {
+		if b[i]&0x80 == 0 {
+			return b[i+1:], true
+		}
+	}
+	return nil, false
+}
@@ -2,6 +2,7 @@ package magic
 
 import (
 	"bytes"
+	"encoding/binary"
 )
 
 var (
@@ -107,3 +108,119 @@ func Mp4(raw []byte, _ uint32) bool {
 	}
 	return bytes.Equal(raw[4:8], []byte("ftyp"))
 }
+
+// HeifParams returns the derived parameter of a HEIF file whose primary item
+// is a derived image: a grid of tiles, an overlay or an identity
+// transformation of another item.
+// The primary item is given by the pitm box and its type by the infe entries
+// of the iinf box, all inside the top level meta box. ISO/IEC 23008-12.
+func HeifParams(raw []byte, _ uint32) map[string]string {
+	meta, ok := isoFindBox(raw, "meta")
+	// meta is a full box: skip the version and flags.
+	if !ok || len(meta) < 4 {
+		return nil
+	}
+	meta = meta[4:]
+
+	pitm, ok := isoFindBox(meta, "pitm")
+	if !ok || len(pitm) < 6 {
+		return nil
+	}
+	primary := uint32(binary.BigEndian.Uint16(pitm[4:6]))
+	if pitm[0] != 0 {
+		if len(pitm) < 8 {
+			return nil
+		}
+		primary = binary.BigEndian.Uint32(pitm[4:8])
+	}
+
+	iinf, ok := isoFindBox(meta, "iinf")
+	if !ok || len(iinf) < 6 {
+		return nil
+	}
+	entries := iinf[6:]
+	if iinf[0] != 0 {
+		if len(iinf) < 8 {
+			return nil
+		}
+		entries = iinf[8:]
+	}
+
+	derived := map[string]string{
+		"grid": "grid",
+		"iovl": "overlay",
+		"iden": "identity",
+	}
+	for i := 0; i < 64; i++ {
+		typ, infe, rest, ok := isoNextBox(entries)
+		if !ok {
+			break
+		}
+		entries = rest
+		// Only infe versions 2 and 3 have the item type.
+		if typ != "infe" || len(infe) < 4 || (infe[0] != 2 && infe[0] != 3) {
+			continue
+		}
+		var id uint32
+		var itemType []byte
+		if infe[0] == 2 && len(infe) >= 12 {
+			id, itemType = uint32(binary.BigEndian.Uint16(infe[4:6])), infe[8:12]
+		} else if infe[0] == 3 && len(infe) >= 14 {
+			id, itemType = binary.BigEndian.Uint32(infe[4:8]), infe[10:14]
+		} else {
+			continue
+		}
+		if id == primary {
+			if d, ok := derived[string(itemType)]; ok {
+				return map[string]string{"derived": d}
+			}
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// isoFindBox returns the payload of the first box with type typ found in b,
+// which holds a sequence of ISO base media file format boxes.
+func isoFindBox(b []byte, typ string) ([]byte, bool) {
+	for i := 0; i < 64; i++ {
+		t, payload, rest, ok := isoNextBox(b)
+		if !ok {
+			return nil, false
+		}
+		if t == typ {
+			return payload, true
+		}
+		b = rest
+	}
+	return nil, false
+}
+
+// isoNextBox splits b into the type and payload of its first box, and the
+// bytes following that box. The payload is truncated when the box is bigger
+// than b.
+func isoNextBox(b []byte) (typ string, payload, rest []byte, ok bool) {
+	if len(b) < 8 {
+		return "", nil, nil, false
+	}
+	size, hdr := uint64(binary.BigEndian.Uint32(b)), uint64(8)
+	typ = string(b[4:8])
+	switch size {
+	case 0:
+		// Box extends to the end of the file.
+		size = uint64(len(b))
+	case 1:
+		if len(b) < 16 {
+			return "", nil, nil, false
+		}
+		size, hdr = binary.BigEndian.Uint64(b[8:16]), 16
+	}
+	if size < hdr {
+		return "", nil, nil, false
+	}
+	if size > uint64(len(b)) {
+		return typ, b[hdr:], nil, true
+	}
+	return typ, b[hdr:size], b[size:], true
+}
@@ -0,0 +1,136 @@
+package magic
+
+import (
+	"bytes"
+	"encoding/binary"
+	"unicode/utf8"
+)
+
+// Vpk matches a Valve Pak archive, used by the games built on the Source
+// engine. The header is the 0x55AA1234 signature and the version, 1 or 2,
+// both little-endian.
+// https://developer.valvesoftware.com/wiki/VPK_(file_format)
+func Vpk(raw []byte, _ uint32) bool {
+	if len(raw) < 8 || !bytes.HasPrefix(raw, []byte{0x34, 0x12, 0xAA, 0x55}) {
+		return false
+	}
+	v := binary.LittleEndian.Uint32(raw[4:8])
+	return v == 1 || v == 2
+}
+
+// Bsp matches a binary space partitioning map of the Source engine, with the
+// "VBSP" signature, or of the Quake II and Quake III engines, with the "IBSP"
+// signature. The signature is followed by the little-endian version.
+// Quake I maps do not have a signature and are not detected.
+// https://developer.valvesoftware.com/wiki/BSP_(Source)
+func Bsp(raw []byte, _ uint32) bool {
+	if len(raw) < 8 {
+		return false
+	}
+	v := binary.LittleEndian.Uint32(raw[4:8])
+	switch string(raw[:4]) {
+	case "VBSP":
+		return v >= 17 && v <= 29
+	case "IBSP":
+		// Quake II, Quake III and the games based on its engine.
+		return v == 38 || v == 46 || v == 47
+	}
+	return false
+}
+
+// QuakePak matches a Quake PAK archive. The "PACK" signature is followed by
+// the little-endian offset and size of the directory, made of 64 bytes entries.
+// Git packfiles have the same signature, followed by a big-endian version,
+// 2 or 3, which would make an unlikely big directory offset.
+// https://quakewiki.org/wiki/.pak
+func QuakePak(raw []byte, limit uint32) bool {
+	if len(raw) < 12 || !bytes.HasPrefix(raw, []byte("PACK")) {
+		return false
+	}
+	if v := binary.BigEndian.Uint32(raw[4:8]); v == 2 || v == 3 {
+		return false
+	}
+	off := binary.LittleEndian.Uint32(raw[4:8])
+	size := binary.LittleEndian.Uint32(raw[8:12])
+	if off < 12 || size%64 != 0 || off+size < off {
+		return false
+	}
+	// When the whole file was provided, the directory must be inside it.
+	if limit == 0 || uint32(len(raw)) < limit {
+		return off+size <= uint32(len(raw))
+	}
+	return true
+}
+
+// DoomWad matches a Doom WAD file, either an IWAD holding the data of a game
+// or a PWAD patching it. The signature is followed by the little-endian
+// number of lumps and offset of the lump directory.
+// https://doomwiki.org/wiki/WAD
+func DoomWad(raw []byte, limit uint32) bool {
+	if len(raw) < 12 ||
+		!bytes.HasPrefix(raw, []byte("IWAD")) && !bytes.HasPrefix(raw, []byte("PWAD")) {
+		return false
+	}
+	lumps := binary.LittleEndian.Uint32(raw[4:8])
+	off := binary.LittleEndian.Uint32(raw[8:12])
+	// Both fields are signed 32 bits integers.
+	if lumps > 1<<31-1 || off < 12 || off > 1<<31-1 {
+		return false
+	}
+	// Directory entries are 16 bytes long.
+	if limit == 0 || uint32(len(raw)) < limit {
+		return uint64(off)+16*uint64(lumps) <= uint64(len(raw))
+	}
+	return true
+}
+
+// Vtf matches a Valve Texture Format image. The "VTF\x00" signature is
+// followed by the little-endian major and minor versions, 7.0 to 7.5.
+// https://developer.valvesoftware.com/wiki/VTF_(Valve_Texture_Format)
+func Vtf(raw []byte, _ uint32) bool {
+	return len(raw) >= 12 &&
+		bytes.HasPrefix(raw, []byte("VTF\x00")) &&
+		binary.LittleEndian.Uint32(raw[4:8]) == 7 &&
+		binary.LittleEndian.Uint32(raw[8:12]) <= 5
+}
+
+// Nbt matches an uncompressed Minecraft Named Binary Tag file. The file is a
+// compound tag, 0x0A, with a big-endian name length and a name, followed by
+// the first tag of the compound, which also has a type, 1 to 12, and a name.
+// Most NBT files, like level.dat, are gzip compressed and detected as gzip.
+// Region files (.mca) start with a table of chunk locations without a
+// signature and are not detected.
+// https://minecraft.wiki/w/NBT_format
+func Nbt(raw []byte, _ uint32) bool {
+	if len(raw) == 0 || raw[0] != 0x0A {
+		return false
+	}
+	rest, ok := nbtName(raw[1:])
+	if !ok || len(rest) == 0 || rest[0] == 0 || rest[0] > 12 {
+		return false
+	}
+	_, ok = nbtName(rest[1:])
+	return ok
+}
+
+// nbtName skips the name of a tag, returning the remaining bytes.
+// Names are short, printable strings.
+func nbtName(raw []byte) ([]byte, bool) {
+	if len(raw) < 2 {
+		return nil, false
+	}
+	n := int(binary.BigEndian.Uint16(raw))
+	if n > 64 || len(raw) < 2+n {
+		return nil, false
+	}
+	name := raw[2 : 2+n]
+	if !utf8.Valid(name) {
+		return nil, false
+	}
+	for _, c := range name {
+		if c < 0x20 || c == 0x7F {
+			return nil, false
+		}
+	}
+	return raw[2+n:], true
+}
@@ -1,6 +1,9 @@
 package magic
 
-import "bytes"
+import (
+	"bytes"
+	"encoding/binary"
+)
 
 var (
 	// Png matches a Portable Network Graphics file.
@@ -48,6 +51,18 @@ var (
 	Jxs = prefix([]byte{0x00, 0x00, 0x00, 0x0C, 0x4A, 0x58, 0x53, 0x20, 0x0D, 0x0A, 0x87, 0x0A})
 	// Jxr matches Microsoft HD JXR photo file.
 	Jxr = prefix([]byte{0x49, 0x49, 0xBC, 0x01})
+	// FigmaFig matches a Figma design file, stored with the Kiwi serialization
+	// format behind a "fig-kiwi" header.
+	FigmaFig = prefix([]byte("fig-kiwi"))
+	// Affinity matches the documents of Serif Affinity Designer, Photo and
+	// Publisher. The three applications share the same container, which does
+	// not have a documented product tag in its header.
+	Affinity = prefix([]byte{0x00, 0xFF, 0x4B, 0x41})
+	// ClipStudio matches a Clip Studio Paint illustration. The file is a
+	// sequence of chunks, one of them holding an SQLite database.
+	ClipStudio = prefix([]byte("CSFCHUNK"))
+	// AdobeAse matches an Adobe Swatch Exchange palette.
+	AdobeAse = prefix([]byte("ASEF\x00\x01\x00\x00"))
 )
 
 func jpeg2k(sig []byte) Detector {
@@ -71,6 +86,72 @@ func Webp(raw []byte, _ uint32) bool {
 		bytes.Equal(raw[8:12], []byte{0x57, 0x45, 0x42, 0x50})
 }
 
+// WebpParams returns the lossless and alpha parameters of a WebP file.
+// The parameters are read from the first image chunk: "VP8 " for lossy and
+// "VP8L" for lossless bitstreams. In the extended file format, the image
+// chunk is preceded by a "VP8X" chunk having the alpha flag, and possibly by
+// other metadata chunks.
+// https://developers.google.com/speed/webp/docs/riff_container
+func WebpParams(raw []byte, _ uint32) map[string]string {
+	ps := map[string]string{}
+	// Stop after a few chunks if no image chunk was found.
+	for i, o := 0, 12; i < 8 && len(raw) >= o+8; i++ {
+		size := int(binary.LittleEndian.Uint32(raw[o+4 : o+8]))
+		data := raw[o+8:]
+		switch string(raw[o : o+4]) {
+		case "VP8X":
+			if len(data) > 0 {
+				ps["alpha"] = boolParam(data[0]&0x10 != 0)
+			}
+		case "VP8 ":
+			ps["lossless"] = "false"
+			return ps
+		case "VP8L":
+			ps["lossless"] = "true"
+			// The VP8L header is the 0x2F signature followed by 14 bits of
+			// width, 14 bits of height and the alpha_is_used bit.
+			if _, ok := ps["alpha"]; !ok && len(data) > 4 && data[0] == 0x2F {
+				ps["alpha"] = boolParam(binary.LittleEndian.Uint32(data[1:5])>>28&1 == 1)
+			}
+			return ps
+		}
+		// Chunks are padded to an even size.
+		o += 8 + size + size&1
+		if o < 0 {
+			break
+		}
+	}
+
+	return ps
+}
+
+func boolParam(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
+
+// IccProfile matches an International Color Consortium profile.
+// The 128 bytes profile header starts with the big-endian profile size and
+// has the "acsp" profile file signature at offset 36.
+// https://www.color.org/specification/ICC.1-2022-05.pdf
+func IccProfile(raw []byte, limit uint32) bool {
+	if len(raw) < 40 || !bytes.Equal(raw[36:40], []byte("acsp")) {
+		return false
+	}
+	size := binary.BigEndian.Uint32(raw[:4])
+	// The profile is at least as big as its header and, when the whole file
+	// was provided, its size is the size of the file.
+	if size < 128 {
+		return false
+	}
+	if limit == 0 || uint32(len(raw)) < limit {
+		return size == uint32(len(raw))
+	}
+	return true
+}
+
 // Dwg matches a CAD drawing file.
 func Dwg(raw []byte, _ uint32) bool {
 	if len(raw) < 6 || raw[0] != 0x41 || raw[1] != 0x43 {
@@ -108,3 +189,58 @@ func Jxl(raw []byte, _ uint32) bool {
 	return bytes.HasPrefix(raw, []byte{0xFF, 0x0A}) ||
 		bytes.HasPrefix(raw, []byte("\x00\x00\x00\x0cJXL\x20\x0d\x0a\x87\x0a"))
 }
+
+// AdobeAco matches an Adobe Color swatch palette. The file starts with a version
+// 1 section: the version, the number of colors and 10 bytes per color, each
+// starting with a color space ID. A version 2 section can follow.
+// https://www.adobe.com/devnet-apps/photoshop/fileformatashtml/#50577411_pgfId-1055819
+func AdobeAco(raw []byte, limit uint32) bool {
+	if len(raw) < 14 || binary.BigEndian.Uint16(raw) != 1 {
+		return false
+	}
+	count := int(binary.BigEndian.Uint16(raw[2:]))
+	if count == 0 {
+		return false
+	}
+	colors := raw[4:]
+	for i := 0; i < count && len(colors) >= 10; i++ {
+		switch binary.BigEndian.Uint16(colors) {
+		case 0, 1, 2, 7, 8, 9: // RGB, HSB, CMYK, Lab, grayscale, wide CMYK.
+		default:
+			return false
+		}
+		colors = colors[10:]
+	}
+	if len(raw) < 4+10*count {
+		// Only a truncated file can be too short for its colors.
+		return limit > 0 && uint32(len(raw)) >= limit
+	}
+	// The version 1 section is either the whole file or followed by version 2.
+	return len(colors) == 0 || len(colors) >= 2 && binary.BigEndian.Uint16(colors) == 2
+}
+
+// Exr matches an OpenEXR image. The magic number is followed by a 4 bytes
+// little-endian version field, whose low byte is the format version, 2.
+// https://openexr.com/en/latest/OpenEXRFileLayout.html#version-field
+func Exr(raw []byte, _ uint32) bool {
+	return len(raw) >= 8 &&
+		bytes.HasPrefix(raw, []byte{0x76, 0x2F, 0x31, 0x01}) &&
+		raw[4] == 2
+}
+
+// ExrParams reads the flags of the OpenEXR version field: single part tiled,
+// non-image (deep data) and multipart. Only the flags which are set are
+// returned as parameters.
+func ExrParams(raw []byte, _ uint32) map[string]string {
+	if len(raw) < 8 {
+		return nil
+	}
+	v := binary.LittleEndian.Uint32(raw[4:8])
+	ps := map[string]string{}
+	for flag, name := range map[uint32]string{0x200: "tiled", 0x800: "deep", 0x1000: "multipart"} {
+		if v&flag != 0 {
+			ps[name] = "true"
+		}
+	}
+	return ps
+}
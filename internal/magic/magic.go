@@ -12,7 +12,11 @@ type (
 	// of bytes received and is used to tell if the byte slice represents the
 	// whole file or is just the header of a file: len(raw) < limit or len(raw)>limit.
 	Detector func(raw []byte, limit uint32) bool
-	xmlSig   struct {
+	// Params receives the raw data of a file matched by a Detector and returns
+	// the optional MIME parameters describing it, like lossless=true for WebP.
+	// Parameters which cannot be found in raw are omitted.
+	Params func(raw []byte, limit uint32) map[string]string
+	xmlSig struct {
 		// the local name of the root tag
 		localName []byte
 		// the namespace of the XML document
@@ -67,6 +67,16 @@ func TestMagic(t *testing.T) {
 		raw:      "{}\n{}\n{}",
 		limit:    10,
 		res:      true,
+	}, {
+		name:     "CBOR diagnostic notation",
+		detector: CborDiagnostic,
+		raw:      `{1: h'00', "t": 0("2013-03-21T20:04:00Z")}`,
+		res:      true,
+	}, {
+		name:     "JSON is not CBOR diagnostic notation",
+		detector: CborDiagnostic,
+		raw:      `{"key": [1, 2, {"nested": "h'00' 32(a)"}], "n": 12}`,
+		res:      false,
 	}, {
 		name:     "MachO class or Fat but last byte > \\x14",
 		detector: MachO,
@@ -245,3 +255,35 @@ func BenchmarkJSON(b *testing.B) {
 		}
 	}
 }
+
+func TestHDL(t *testing.T) {
+	verilog := "`timescale 1ns / 1ps\n// counter\nmodule counter(input clk, output reg [3:0] q);\n  always @(posedge clk) q <= q + 1;\nendmodule\n"
+	vhdl := "library IEEE;\nuse IEEE.STD_LOGIC_1164.ALL;\n\nentity counter is\n  port (clk : in std_logic);\nend counter;\n"
+	spice := "RC low pass filter\n* comment\nV1 in 0 AC 1\nR1 in out 1k\nC1 out 0 1u\n.ac dec 10 1 1meg\n.end\n"
+	tcases := []struct {
+		name    string
+		raw     string
+		verilog bool
+		vhdl    bool
+		spice   bool
+	}{
+		{"verilog", verilog, true, false, false},
+		{"vhdl", vhdl, false, true, false},
+		{"spice", spice, false, false, true},
+		{"c", "#include <stdio.h>\nint main() {\n  return 0;\n}\n", false, false, false},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := []byte(tc.raw)
+			if got := Verilog(raw, 0); got != tc.verilog {
+				t.Errorf("Verilog: expected: %t; got: %t", tc.verilog, got)
+			}
+			if got := Vhdl(raw, 0); got != tc.vhdl {
+				t.Errorf("Vhdl: expected: %t; got: %t", tc.vhdl, got)
+			}
+			if got := Spice(raw, 0); got != tc.spice {
+				t.Errorf("Spice: expected: %t; got: %t", tc.spice, got)
+			}
+		})
+	}
+}
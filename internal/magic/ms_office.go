@@ -2,7 +2,9 @@ package magic
 
 import (
 	"bytes"
+	"compress/flate"
 	"encoding/binary"
+	"io"
 )
 
 // Xlsx matches a Microsoft Excel 2007 file.
@@ -20,6 +22,55 @@ func Pptx(raw []byte, limit uint32) bool {
 	return zipContains(raw, []byte("ppt/"), true)
 }
 
+var (
+	// Dotx matches a Microsoft Word 2007 template.
+	Dotx = ooxmlContentType("application/vnd.openxmlformats-officedocument.wordprocessingml.template.main+xml")
+	// Xltx matches a Microsoft Excel 2007 template.
+	Xltx = ooxmlContentType("application/vnd.openxmlformats-officedocument.spreadsheetml.template.main+xml")
+	// Potx matches a Microsoft PowerPoint 2007 template.
+	Potx = ooxmlContentType("application/vnd.openxmlformats-officedocument.presentationml.template.main+xml")
+	// Ppsx matches a Microsoft PowerPoint 2007 slideshow.
+	Ppsx = ooxmlContentType("application/vnd.openxmlformats-officedocument.presentationml.slideshow.main+xml")
+)
+
+// ooxmlContentType creates a Detector for Office Open XML files whose main
+// part has the content type ct. Templates and slideshows share the layout of
+// the documents and presentations, and only differ by the content type
+// declared for the main part in the [Content_Types].xml entry.
+func ooxmlContentType(ct string) Detector {
+	return func(raw []byte, _ uint32) bool {
+		return bytes.Contains(ooxmlContentTypes(raw), []byte(`ContentType="`+ct+`"`))
+	}
+}
+
+// ooxmlContentTypes returns the content of the [Content_Types].xml entry, or
+// its beginning if the input is truncated.
+func ooxmlContentTypes(raw []byte) []byte {
+	var out []byte
+	zipEach(raw, func(name string, entry []byte) bool {
+		if name != "[Content_Types].xml" {
+			return true
+		}
+		method := binary.LittleEndian.Uint16(entry[8:])
+		size := int(binary.LittleEndian.Uint32(entry[18:]))
+		start := 30 + len(name) + int(binary.LittleEndian.Uint16(entry[28:]))
+		if start > len(entry) {
+			return false
+		}
+		data := entry[start:]
+		switch method {
+		case 0: // Stored.
+			out = data[:min(size, len(data))]
+		case 8: // Deflated.
+			// The file is a few KB, and an unexpected EOF only means the
+			// input was truncated.
+			out, _ = io.ReadAll(io.LimitReader(flate.NewReader(bytes.NewReader(data)), 1<<16))
+		}
+		return false
+	})
+	return out
+}
+
 // Ole matches an Open Linking and Embedding file.
 //
 // https://en.wikipedia.org/wiki/Object_Linking_and_Embedding
@@ -2,6 +2,8 @@ package magic
 
 import (
 	"bytes"
+	"encoding/base64"
+	"strconv"
 	"time"
 
 	"github.com/gabriel-vasile/mimetype/internal/charset"
@@ -65,6 +67,46 @@ var (
 	Threemf = xml(newXMLSig("model", `xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02"`))
 	// Xfdf matches a XML Forms Data Format file.
 	Xfdf = xml(newXMLSig("xfdf", `xmlns="http://ns.adobe.com/xfdf/"`))
+	// Soap matches a SOAP 1.1 or SOAP 1.2 message envelope.
+	// The envelope element is usually namespace prefixed, so the prefixed
+	// forms only check that the SOAP namespace is declared after the root tag.
+	Soap = xml(
+		newXMLSig("Envelope", `xmlns="http://www.w3.org/2003/05/soap-envelope"`),
+		newXMLSig("soap:Envelope", `"http://www.w3.org/2003/05/soap-envelope"`),
+		newXMLSig("soapenv:Envelope", `"http://www.w3.org/2003/05/soap-envelope"`),
+		newXMLSig("env:Envelope", `"http://www.w3.org/2003/05/soap-envelope"`),
+		newXMLSig("SOAP-ENV:Envelope", `"http://www.w3.org/2003/05/soap-envelope"`),
+		newXMLSig("Envelope", `xmlns="http://schemas.xmlsoap.org/soap/envelope/"`),
+		newXMLSig("soap:Envelope", `"http://schemas.xmlsoap.org/soap/envelope/"`),
+		newXMLSig("soapenv:Envelope", `"http://schemas.xmlsoap.org/soap/envelope/"`),
+		newXMLSig("env:Envelope", `"http://schemas.xmlsoap.org/soap/envelope/"`),
+		newXMLSig("SOAP-ENV:Envelope", `"http://schemas.xmlsoap.org/soap/envelope/"`),
+	)
+	// Wsdl matches a Web Services Description Language 1.1 or 2.0 file.
+	Wsdl = xml(
+		newXMLSig("definitions", `xmlns="http://schemas.xmlsoap.org/wsdl/"`),
+		newXMLSig("wsdl:definitions", `xmlns:wsdl="http://schemas.xmlsoap.org/wsdl/"`),
+		newXMLSig("description", `xmlns="http://www.w3.org/ns/wsdl"`),
+		newXMLSig("wsdl:description", `xmlns:wsdl="http://www.w3.org/ns/wsdl"`),
+	)
+	// Wadl matches a Web Application Description Language file.
+	Wadl = xml(newXMLSig("application", `xmlns="http://wadl.dev.java.net/2009/02"`))
+	// Saml matches a Security Assertion Markup Language assertion or protocol response.
+	Saml = xml(
+		newXMLSig("Assertion", `xmlns="urn:oasis:names:tc:SAML:2.0:assertion"`),
+		newXMLSig("saml:Assertion", `xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion"`),
+		newXMLSig("saml2:Assertion", `xmlns:saml2="urn:oasis:names:tc:SAML:2.0:assertion"`),
+		newXMLSig("saml:Assertion", `xmlns:saml="urn:oasis:names:tc:SAML:1.0:assertion"`),
+		newXMLSig("samlp:Response", `xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol"`),
+		newXMLSig("saml2p:Response", `xmlns:saml2p="urn:oasis:names:tc:SAML:2.0:protocol"`),
+	)
+	// XmlDsig matches a detached XML Signature document.
+	// SAML documents embed signatures too, so Saml must be checked first.
+	XmlDsig = xml(
+		newXMLSig("Signature", `xmlns="http://www.w3.org/2000/09/xmldsig#"`),
+		newXMLSig("ds:Signature", `xmlns:ds="http://www.w3.org/2000/09/xmldsig#"`),
+		newXMLSig("dsig:Signature", `xmlns:dsig="http://www.w3.org/2000/09/xmldsig#"`),
+	)
 	// VCard matches a Virtual Contact File.
 	VCard = ciPrefix([]byte("BEGIN:VCARD\n"), []byte("BEGIN:VCARD\r\n"))
 	// ICalendar matches a iCalendar file.
@@ -120,6 +162,14 @@ var (
 	)
 	// Rtf matches a Rich Text Format file.
 	Rtf = prefix([]byte("{\\rtf"))
+	// Ppd matches a PostScript Printer Description file.
+	Ppd = prefix([]byte("*PPD-Adobe:"))
+	// GimpPalette matches a GIMP palette.
+	GimpPalette = prefix([]byte("GIMP Palette\n"), []byte("GIMP Palette\r\n"))
+	// MayaAscii matches an Autodesk Maya ASCII scene.
+	MayaAscii = prefix([]byte("//Maya ASCII "))
+	// VobSubIdx matches the index file of VobSub DVD subtitles.
+	VobSubIdx = prefix([]byte("# VobSub index file, v"))
 )
 
 // Text matches a plain text file.
@@ -366,6 +416,1163 @@ func Vtt(raw []byte, limit uint32) bool {
 		bytes.Equal(raw, []byte{0x57, 0x45, 0x42, 0x56, 0x54, 0x54}) // "WEBVTT"
 }
 
+// DNSZone matches a DNS master (zone) file as described in RFC 1035.
+// Zone files have few distinctive markers, so the input must contain an
+// SOA record, and either a $TTL or $ORIGIN directive or an NS record.
+func DNSZone(raw []byte, limit uint32) bool {
+	raw = dropLastLine(raw, limit)
+	hasSOA, hasDirective, hasNS := false, false, false
+	var l []byte
+	for len(raw) != 0 {
+		l, raw = scanLine(raw)
+		// Drop comments.
+		if i := bytes.IndexByte(l, ';'); i != -1 {
+			l = l[:i]
+		}
+		fields := bytes.Fields(l)
+		if len(fields) == 0 {
+			continue
+		}
+		if bytes.Equal(fields[0], []byte("$TTL")) ||
+			bytes.Equal(fields[0], []byte("$ORIGIN")) {
+			hasDirective = true
+			continue
+		}
+		for i := 0; i < len(fields)-1; i++ {
+			if !bytes.EqualFold(fields[i], []byte("IN")) {
+				continue
+			}
+			if bytes.EqualFold(fields[i+1], []byte("SOA")) {
+				hasSOA = true
+			} else if bytes.EqualFold(fields[i+1], []byte("NS")) {
+				hasNS = true
+			}
+			break
+		}
+	}
+
+	return hasSOA && (hasDirective || hasNS)
+}
+
+// SSHAuthorizedKeys matches an OpenSSH authorized_keys file.
+func SSHAuthorizedKeys(raw []byte, limit uint32) bool {
+	ok, _ := sshKeys(raw, limit)
+	return ok
+}
+
+// SSHKnownHosts matches an OpenSSH known_hosts file. Only files having hashed
+// host names or marker lines are recognized; plain host names are
+// indistinguishable from authorized_keys options.
+func SSHKnownHosts(raw []byte, limit uint32) bool {
+	ok, knownHosts := sshKeys(raw, limit)
+	return ok && knownHosts
+}
+
+// sshKeys reports whether all lines of raw are SSH public key lines, and
+// whether any of those lines is specific to known_hosts files.
+// A key line is an optional prefix (options or host names), a key type and
+// the base64 encoded key, whose first bytes encode the length of the key
+// type and always look like "AAAA".
+func sshKeys(raw []byte, limit uint32) (ok, knownHosts bool) {
+	raw = dropLastLine(raw, limit)
+	keyTypes := [][]byte{
+		[]byte("ssh-rsa"),
+		[]byte("ssh-dss"),
+		[]byte("ssh-ed25519"),
+		[]byte("ecdsa-sha2-nistp256"),
+		[]byte("ecdsa-sha2-nistp384"),
+		[]byte("ecdsa-sha2-nistp521"),
+		[]byte("sk-ssh-ed25519@openssh.com"),
+		[]byte("sk-ecdsa-sha2-nistp256@openssh.com"),
+	}
+	isKeyType := func(f []byte) bool {
+		for _, kt := range keyTypes {
+			if bytes.Equal(f, kt) {
+				return true
+			}
+		}
+		return false
+	}
+
+	keys := 0
+	var l []byte
+	for len(raw) != 0 {
+		l, raw = scanLine(raw)
+		l = trimLWS(l)
+		if len(l) == 0 || l[0] == '#' {
+			continue
+		}
+		fields := bytes.Fields(l)
+		// Options and host names are at most 2 fields, the second one being
+		// a known_hosts marker like @cert-authority or @revoked.
+		i := 0
+		for ; i < len(fields) && i < 3 && !isKeyType(fields[i]); i++ {
+		}
+		if i == 3 || i >= len(fields)-1 {
+			return false, false
+		}
+		if !bytes.HasPrefix(fields[i+1], []byte("AAAA")) {
+			return false, false
+		}
+		if i > 0 && (bytes.HasPrefix(fields[0], []byte("|1|")) || fields[0][0] == '@') {
+			knownHosts = true
+		}
+		keys++
+	}
+
+	return keys > 0, knownHosts
+}
+
+// Jwt matches a JSON Web Token, or any JSON Web Signature, in compact
+// serialization: three dot separated base64url segments.
+func Jwt(raw []byte, limit uint32) bool {
+	n, hasEnc := joseCompact(raw)
+	return n == 3 && !hasEnc
+}
+
+// Jwe matches a JSON Web Encryption object in compact serialization: five dot
+// separated base64url segments with an "enc" header parameter.
+func Jwe(raw []byte, limit uint32) bool {
+	n, hasEnc := joseCompact(raw)
+	return n == 5 && hasEnc
+}
+
+// joseCompact returns the number of segments of a JOSE compact serialization
+// and whether its protected header has the "enc" parameter. The input must
+// consist of the serialized object only, and the first segment must decode
+// to a JSON object having the "alg" parameter.
+// It returns 0 segments when raw is not a JOSE compact serialization.
+func joseCompact(raw []byte) (segments int, hasEnc bool) {
+	raw = trimRWS(trimLWS(raw))
+	// The header always begins with `{"` which is eyJ in base64.
+	if !bytes.HasPrefix(raw, []byte("eyJ")) {
+		return 0, false
+	}
+	for _, b := range raw {
+		if b == '.' {
+			segments++
+		} else if !('A' <= b && b <= 'Z' || 'a' <= b && b <= 'z' ||
+			'0' <= b && b <= '9' || b == '-' || b == '_') {
+			return 0, false
+		}
+	}
+	segments++
+
+	header, _, found := bytes.Cut(raw, []byte("."))
+	if !found {
+		return 0, false
+	}
+	dec := make([]byte, base64.RawURLEncoding.DecodedLen(len(header)))
+	dn, err := base64.RawURLEncoding.Decode(dec, header)
+	if err != nil {
+		return 0, false
+	}
+	// The eyJ prefix makes sure the header is a JSON object.
+	h := dec[:dn]
+	if _, err := json.Scan(h); err != nil {
+		return 0, false
+	}
+	if jsonValue(h, []byte(`"alg"`)) == nil {
+		return 0, false
+	}
+	hasEnc = jsonValue(h, []byte(`"enc"`)) != nil
+
+	return segments, hasEnc
+}
+
+// CborDiagnostic matches CBOR Extended Diagnostic Notation (EDN), a JSON
+// superset for representing CBOR data items as text. RFC 8949, section 8.
+// The input must start like an EDN map, array or tagged value, and must
+// contain, outside of text strings, at least one construct specific to EDN:
+// a tag number followed by a parenthesized value, as in 32("http://a.b"),
+// or a byte string literal, as in h'0102' or b64'AQI'.
+func CborDiagnostic(raw []byte, limit uint32) bool {
+	raw = trimLWS(raw)
+	if len(raw) == 0 {
+		return false
+	}
+	if raw[0] != '{' && raw[0] != '[' && !('0' <= raw[0] && raw[0] <= '9') {
+		return false
+	}
+
+	isDelim := func(i int) bool {
+		if i < 0 {
+			return true
+		}
+		switch raw[i] {
+		case '{', '[', '(', ',', ':', ' ', '\t', '\r', '\n':
+			return true
+		}
+		return false
+	}
+	markers := 0
+	for i := 0; i < len(raw); i++ {
+		switch b := raw[i]; {
+		case b == '"' || b == '\'':
+			// Skip text strings and the content of byte strings.
+			for i++; i < len(raw) && raw[i] != b; i++ {
+				if raw[i] == '\\' {
+					i++
+				}
+			}
+		case '0' <= b && b <= '9' && isDelim(i-1):
+			j := i
+			for ; j < len(raw) && '0' <= raw[j] && raw[j] <= '9'; j++ {
+			}
+			if j < len(raw) && raw[j] == '(' {
+				markers++
+			}
+			i = j - 1
+		case (b == 'h' || b == 'b') && isDelim(i-1):
+			for _, p := range [][]byte{[]byte("h'"), []byte("h32'"), []byte("b32'"), []byte("b64'")} {
+				if bytes.HasPrefix(raw[i:], p) {
+					markers++
+					i += len(p) - 2
+					break
+				}
+			}
+		}
+	}
+
+	return markers > 0
+}
+
+// CubeLut matches an Adobe/DaVinci Resolve .cube color lookup table.
+// The header lines, which come before the table data, must contain the
+// LUT_1D_SIZE or LUT_3D_SIZE keyword followed by the table size.
+//
+// Autodesk .3dl lookup tables are not detected: they are just grids of
+// whitespace separated integers, with no keyword to rely on.
+func CubeLut(raw []byte, limit uint32) bool {
+	keywords := [][]byte{
+		[]byte("TITLE"),
+		[]byte("DOMAIN_MIN"),
+		[]byte("DOMAIN_MAX"),
+		[]byte("LUT_1D_INPUT_RANGE"),
+		[]byte("LUT_3D_INPUT_RANGE"),
+	}
+	var l []byte
+	for len(raw) != 0 {
+		l, raw = scanLine(raw)
+		fields := bytes.Fields(l)
+		if len(fields) == 0 || fields[0][0] == '#' {
+			continue
+		}
+		if bytes.Equal(fields[0], []byte("LUT_1D_SIZE")) ||
+			bytes.Equal(fields[0], []byte("LUT_3D_SIZE")) {
+			return len(fields) == 2 && isDigits(fields[1])
+		}
+		isKeyword := false
+		for _, k := range keywords {
+			if bytes.Equal(fields[0], k) {
+				isKeyword = true
+				break
+			}
+		}
+		// Table data reached, or the input is not a .cube file.
+		if !isKeyword {
+			return false
+		}
+	}
+
+	return false
+}
+
+func isDigits(b []byte) bool {
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return len(b) > 0
+}
+
+// OcioConfig matches an OpenColorIO YAML configuration file, identified by
+// its top level ocio_profile_version key.
+func OcioConfig(raw []byte, limit uint32) bool {
+	key := []byte("ocio_profile_version:")
+	var l []byte
+	for len(raw) != 0 {
+		l, raw = scanLine(raw)
+		// Keys must not be indented to be top level.
+		if !bytes.HasPrefix(l, key) {
+			continue
+		}
+		v := trimRWS(trimLWS(l[len(key):]))
+		// Versions look like 1, 2 or 2.1.
+		return len(v) > 0 && isDigits(bytes.Replace(v, []byte("."), nil, 1))
+	}
+
+	return false
+}
+
+// Gcode matches a G-code program used by 3D printers and CNC machines.
+// All lines must be comments or commands, and at least 3 lines must be G or M
+// commands. The parameters of G commands must be words made of a letter and a
+// number, as in "G1 X10.5 Y-3 F1500".
+func Gcode(raw []byte, limit uint32) bool {
+	raw = dropLastLine(raw, limit)
+	commands := 0
+	var l []byte
+	for len(raw) != 0 {
+		l, raw = scanLine(raw)
+		if i := bytes.IndexByte(l, ';'); i != -1 {
+			l = l[:i]
+		}
+		fields := bytes.Fields(l)
+		// Skip empty lines, parenthesized comments and the optional % program
+		// delimiters used by CNC programs.
+		if len(fields) == 0 || fields[0][0] == '(' || bytes.Equal(fields[0], []byte("%")) {
+			continue
+		}
+		// Drop line numbers.
+		if gcodeWord(fields[0]) && (fields[0][0] == 'N' || fields[0][0] == 'n') {
+			fields = fields[1:]
+			if len(fields) == 0 {
+				continue
+			}
+		}
+		if !gcodeWord(fields[0]) {
+			return false
+		}
+		switch fields[0][0] {
+		case 'G', 'g':
+			for _, f := range fields[1:] {
+				if f[0] != '(' && !gcodeWord(f) {
+					return false
+				}
+			}
+			commands++
+		case 'M', 'm':
+			// M commands like M117 take free text, so parameters are not checked.
+			commands++
+		case 'T', 't':
+		default:
+			return false
+		}
+	}
+
+	return commands >= 3
+}
+
+// gcodeWord checks if w is a letter followed by a, possibly signed, decimal number.
+func gcodeWord(w []byte) bool {
+	if len(w) < 2 || !('A' <= w[0] && w[0] <= 'Z' || 'a' <= w[0] && w[0] <= 'z') {
+		return false
+	}
+	w = w[1:]
+	if w[0] == '-' || w[0] == '+' {
+		w = w[1:]
+	}
+	// Accept the decimal separator once.
+	return isDigits(bytes.Replace(w, []byte("."), nil, 1))
+}
+
+// KiCad matches a KiCad board, schematic or library file. These are
+// s-expressions whose root list is named kicad_pcb, kicad_sch, and so on.
+func KiCad(raw []byte, limit uint32) bool {
+	return bytes.HasPrefix(trimLWS(raw), []byte("(kicad_"))
+}
+
+// Gerber matches a Gerber (RS-274X) PCB image file.
+// The file starts with its format specification (%FS) or unit (%MO)
+// extended commands, which can be preceded by G04 comments and by X2 file
+// attributes, like %TF.GenerationSoftware.
+func Gerber(raw []byte, limit uint32) bool {
+	var l []byte
+	for len(raw) != 0 {
+		l, raw = scanLine(raw)
+		l = trimLWS(l)
+		if len(l) == 0 ||
+			bytes.HasPrefix(l, []byte("G04")) ||
+			bytes.HasPrefix(l, []byte("%TF")) {
+			continue
+		}
+		return bytes.HasPrefix(l, []byte("%FS")) || bytes.HasPrefix(l, []byte("%MO"))
+	}
+
+	return false
+}
+
+// Verilog matches a Verilog or SystemVerilog hardware description source.
+// The input must declare a module, as in "module counter(", and have at
+// least one more line starting with a module item keyword or a
+// compiler directive like `timescale.
+func Verilog(raw []byte, limit uint32) bool {
+	hasModule, hasItem := false, false
+	items := []string{"input", "output", "inout", "wire", "reg", "logic",
+		"assign", "always", "always_ff", "always_comb", "initial",
+		"parameter", "endmodule", "`timescale", "`include", "`define"}
+	var l []byte
+	for len(raw) != 0 && !(hasModule && hasItem) {
+		l, raw = scanLine(raw)
+		fields := bytes.Fields(l)
+		if len(fields) == 0 || bytes.HasPrefix(fields[0], []byte("//")) {
+			continue
+		}
+		if string(fields[0]) == "module" && len(fields) > 1 {
+			name := fields[1]
+			if i := bytes.IndexAny(name, "(#;"); i != -1 {
+				name = name[:i]
+			}
+			hasModule = hasModule || isIdent(name)
+			continue
+		}
+		for _, it := range items {
+			if string(fields[0]) == it || bytes.HasPrefix(fields[0], []byte(it+"(")) {
+				hasItem = true
+				break
+			}
+		}
+	}
+
+	return hasModule && hasItem
+}
+
+// Vhdl matches a VHDL hardware description source.
+// VHDL is case insensitive. The input must have an entity or architecture
+// declaration, as in "entity counter is", and a library or use clause.
+func Vhdl(raw []byte, limit uint32) bool {
+	hasUnit, hasClause := false, false
+	var l []byte
+	for len(raw) != 0 && !(hasUnit && hasClause) {
+		l, raw = scanLine(raw)
+		fields := bytes.Fields(l)
+		if len(fields) == 0 || bytes.HasPrefix(fields[0], []byte("--")) {
+			continue
+		}
+		switch {
+		case bytes.EqualFold(fields[0], []byte("library")),
+			bytes.EqualFold(fields[0], []byte("use")):
+			hasClause = len(fields) > 1 && bytes.HasSuffix(fields[len(fields)-1], []byte(";"))
+		case bytes.EqualFold(fields[0], []byte("entity")):
+			hasUnit = len(fields) > 2 && isIdent(fields[1]) && bytes.EqualFold(fields[2], []byte("is"))
+		case bytes.EqualFold(fields[0], []byte("architecture")):
+			hasUnit = len(fields) > 4 && isIdent(fields[1]) &&
+				bytes.EqualFold(fields[2], []byte("of")) && bytes.EqualFold(fields[4], []byte("is"))
+		}
+	}
+
+	return hasUnit && hasClause
+}
+
+// Spice matches a SPICE circuit netlist.
+// The first line of a netlist is its title, followed by element lines, like
+// "R1 in out 1k", and dot commands, like ".tran 1n 10u". The input must have
+// at least 2 element lines and one analysis, model or end dot command.
+func Spice(raw []byte, limit uint32) bool {
+	// Skip the title line.
+	_, raw = scanLine(raw)
+	elements, hasCommand := 0, false
+	commands := []string{".model", ".subckt", ".tran", ".ac", ".dc", ".op", ".end"}
+	var l []byte
+	for len(raw) != 0 {
+		l, raw = scanLine(raw)
+		fields := bytes.Fields(l)
+		// Skip empty, comment and continuation lines.
+		if len(fields) == 0 || fields[0][0] == '*' || fields[0][0] == '+' {
+			continue
+		}
+		if fields[0][0] == '.' {
+			for _, c := range commands {
+				if string(bytes.ToLower(fields[0])) == c {
+					hasCommand = true
+				}
+			}
+			continue
+		}
+		// Element names start with a letter telling the element kind, and
+		// are followed by at least two nodes.
+		if bytes.IndexByte([]byte("BCDEFGHIJKLMQRSTUVWXbcdefghijklmqrstuvwx"), fields[0][0]) == -1 ||
+			!isIdent(fields[0]) || len(fields) < 3 {
+			return false
+		}
+		elements++
+	}
+
+	return elements >= 2 && hasCommand
+}
+
+// isIdent checks if b is an identifier made of letters, digits and
+// underscores, not starting with a digit.
+func isIdent(b []byte) bool {
+	if len(b) == 0 || '0' <= b[0] && b[0] <= '9' {
+		return false
+	}
+	for _, c := range b {
+		if !('A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// OpenScad matches an OpenSCAD model source.
+// The input must call at least 3 distinct OpenSCAD modules, one of them being
+// a solid or a boolean operation, as in "difference() { cube(10); sphere(6); }".
+// Calls are only counted at the start of a statement, which keeps C-like code
+// with coincidental function names from matching.
+// FreeCAD macros are Python scripts and are handled by the Python detector.
+func OpenScad(raw []byte, limit uint32) bool {
+	solids := []string{"cube", "sphere", "cylinder", "polyhedron", "union",
+		"difference", "intersection", "hull", "minkowski", "linear_extrude",
+		"rotate_extrude"}
+	others := []string{"translate", "rotate", "scale", "mirror", "color",
+		"multmatrix", "resize", "offset", "square", "circle", "polygon"}
+	seen := map[string]bool{}
+	hasSolid := false
+	var l []byte
+	for len(raw) != 0 && !(hasSolid && len(seen) >= 3) {
+		l, raw = scanLine(raw)
+		// Statements can be chained on one line: "translate([1, 0, 0]) cube(2);"
+		for l = trimLWS(l); len(l) != 0; l = trimLWS(l) {
+			if bytes.HasPrefix(l, []byte("//")) {
+				break
+			}
+			name := l
+			if i := bytes.IndexByte(l, '('); i != -1 {
+				name = trimRWS(l[:i])
+			}
+			if !scadCall(name, solids) && !scadCall(name, others) {
+				break
+			}
+			seen[string(name)] = true
+			hasSolid = hasSolid || scadCall(name, solids)
+			end := scadArgsEnd(l)
+			if end == -1 {
+				break
+			}
+			l = bytes.TrimLeft(l[end:], " \t{;")
+		}
+	}
+
+	return hasSolid && len(seen) >= 3
+}
+
+func scadCall(name []byte, calls []string) bool {
+	for _, c := range calls {
+		if string(name) == c {
+			return true
+		}
+	}
+	return false
+}
+
+// scadArgsEnd returns the index after the parenthesis closing the first
+// argument list in b, or -1 if the list is not closed on this line.
+func scadArgsEnd(b []byte) int {
+	depth := 0
+	for i, c := range b {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return -1
+}
+
+// OpenMetrics matches the Prometheus and OpenMetrics text exposition formats.
+// Lines are either comments, like "# TYPE http_requests_total counter", or
+// samples made of a metric name, optional labels, a value and an optional
+// timestamp, like `http_requests_total{code="200"} 1027 1395066363000`.
+// At least one TYPE comment and one sample are needed.
+// https://github.com/OpenObservability/OpenMetrics/blob/main/specification/OpenMetrics.md
+func OpenMetrics(raw []byte, limit uint32) bool {
+	raw = dropLastLine(raw, limit)
+	hasType, hasSample := false, false
+	var l []byte
+	for len(raw) != 0 {
+		l, raw = scanLine(raw)
+		l = trimRWS(dropCR(l))
+		if len(l) == 0 {
+			continue
+		}
+		if l[0] == '#' {
+			fields := bytes.Fields(l[1:])
+			if len(fields) == 3 && string(fields[0]) == "TYPE" && metricName(fields[1]) {
+				switch string(fields[2]) {
+				case "counter", "gauge", "histogram", "summary", "untyped",
+					"unknown", "info", "stateset", "gaugehistogram":
+					hasType = true
+				default:
+					return false
+				}
+			}
+			continue
+		}
+		if !metricSample(l) {
+			return false
+		}
+		hasSample = true
+	}
+
+	return hasType && hasSample
+}
+
+// metricSample checks a line is a metric name followed by optional labels, a
+// value, an optional timestamp and an optional OpenMetrics exemplar.
+func metricSample(l []byte) bool {
+	name := l
+	if i := bytes.IndexAny(l, "{ "); i != -1 {
+		name, l = l[:i], l[i:]
+	}
+	if !metricName(name) {
+		return false
+	}
+	if l[0] == '{' {
+		end, quoted := -1, false
+		for i := 1; i < len(l) && end == -1; i++ {
+			switch {
+			case l[i] == '\\' && quoted:
+				i++
+			case l[i] == '"':
+				quoted = !quoted
+			case l[i] == '}' && !quoted:
+				end = i
+			}
+		}
+		if end == -1 {
+			return false
+		}
+		l = l[end+1:]
+	}
+	if i := bytes.Index(l, []byte(" # ")); i != -1 {
+		l = l[:i]
+	}
+	fields := bytes.Fields(l)
+	if len(fields) == 0 || len(fields) > 2 {
+		return false
+	}
+	for _, f := range fields {
+		if _, err := strconv.ParseFloat(string(f), 64); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func metricName(b []byte) bool {
+	if len(b) == 0 || '0' <= b[0] && b[0] <= '9' {
+		return false
+	}
+	for _, c := range b {
+		if !('A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '_' || c == ':') {
+			return false
+		}
+	}
+	return true
+}
+
+// InfluxLine matches the InfluxDB line protocol.
+// Each line is a measurement with optional tags, a set of fields and a
+// timestamp, as in "weather,location=us-midwest temperature=82,raining=f 1465839830100400200".
+// The timestamp is optional in the protocol, but it is required here because
+// it is the part that sets metrics apart from regular text.
+// https://docs.influxdata.com/influxdb/v2/reference/syntax/line-protocol/
+func InfluxLine(raw []byte, limit uint32) bool {
+	raw = dropLastLine(raw, limit)
+	lines := 0
+	var l []byte
+	for len(raw) != 0 {
+		l, raw = scanLine(raw)
+		l = trimRWS(dropCR(l))
+		if len(l) == 0 || l[0] == '#' {
+			continue
+		}
+		parts := influxSplit(l, ' ')
+		if len(parts) != 3 || !isInt(parts[2]) {
+			return false
+		}
+		tags := influxSplit(parts[0], ',')
+		if len(tags[0]) == 0 {
+			return false
+		}
+		for _, t := range tags[1:] {
+			if k, v, ok := bytes.Cut(t, []byte("=")); !ok || len(k) == 0 || len(v) == 0 {
+				return false
+			}
+		}
+		for _, f := range influxSplit(parts[1], ',') {
+			k, v, ok := bytes.Cut(f, []byte("="))
+			if !ok || len(k) == 0 || !influxValue(v) {
+				return false
+			}
+		}
+		lines++
+	}
+
+	return lines > 0
+}
+
+// influxSplit splits b around the unescaped sep bytes found outside double
+// quoted strings.
+func influxSplit(b []byte, sep byte) [][]byte {
+	var parts [][]byte
+	quoted, start := false, 0
+	for i := 0; i < len(b); i++ {
+		switch {
+		case b[i] == '\\':
+			i++
+		case b[i] == '"':
+			quoted = !quoted
+		case b[i] == sep && !quoted:
+			parts = append(parts, b[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, b[start:])
+}
+
+// influxValue checks b is a line protocol field value: a float, an integer
+// with the i or u suffix, a boolean or a double quoted string.
+func influxValue(b []byte) bool {
+	if len(b) == 0 {
+		return false
+	}
+	switch string(b) {
+	case "t", "T", "true", "True", "TRUE", "f", "F", "false", "False", "FALSE":
+		return true
+	}
+	if len(b) > 1 && b[0] == '"' && b[len(b)-1] == '"' {
+		return true
+	}
+	if c := b[len(b)-1]; c == 'i' || c == 'u' {
+		return isInt(b[:len(b)-1])
+	}
+	_, err := strconv.ParseFloat(string(b), 64)
+	return err == nil
+}
+
+// Graphite matches the Graphite plaintext protocol.
+// Each line is a dotted metric path, a value and a Unix timestamp, as in
+// "servers.web01.cpu.load 0.74 1700000000".
+// https://graphite.readthedocs.io/en/latest/feeding-carbon.html#the-plaintext-protocol
+func Graphite(raw []byte, limit uint32) bool {
+	raw = dropLastLine(raw, limit)
+	lines := 0
+	var l []byte
+	for len(raw) != 0 {
+		l, raw = scanLine(raw)
+		fields := bytes.Fields(l)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 || !isInt(fields[2]) {
+			return false
+		}
+		segments := bytes.Split(fields[0], []byte("."))
+		if len(segments) < 2 {
+			return false
+		}
+		for _, s := range segments {
+			if len(s) == 0 || bytes.ContainsAny(s, "=,\"'") {
+				return false
+			}
+		}
+		if _, err := strconv.ParseFloat(string(fields[1]), 64); err != nil {
+			return false
+		}
+		lines++
+	}
+
+	return lines > 0
+}
+
+// isInt checks b is a base 10 integer with an optional minus sign.
+func isInt(b []byte) bool {
+	if len(b) > 0 && b[0] == '-' {
+		b = b[1:]
+	}
+	return isDigits(b)
+}
+
+// NukeScript matches a Foundry Nuke script. Scripts start with a shebang line
+// pointing to the Nuke executable and a version line, followed by node blocks
+// such as "Read {".
+func NukeScript(raw []byte, limit uint32) bool {
+	l, raw := scanLine(raw)
+	if !bytes.HasPrefix(l, []byte("#!")) || !bytes.Contains(bytes.ToLower(l), []byte("nuke")) {
+		return false
+	}
+	hasVersion, nodes := false, 0
+	for len(raw) != 0 && !(hasVersion && nodes > 0) {
+		l, raw = scanLine(raw)
+		fields := bytes.Fields(l)
+		switch {
+		case len(fields) >= 2 && string(fields[0]) == "version" && isNukeVersion(fields[1]):
+			hasVersion = true
+		case len(fields) == 2 && string(fields[1]) == "{" && isIdent(fields[0]):
+			nodes++
+		}
+	}
+
+	return hasVersion && nodes > 0
+}
+
+// isNukeVersion checks b is a version number like "13.0".
+func isNukeVersion(b []byte) bool {
+	major, minor, ok := bytes.Cut(b, []byte("."))
+	return ok && isDigits(major) && isDigits(minor)
+}
+
+// Fcpxml matches a Final Cut Pro XML interchange document. The fcpxml root
+// element must have a version attribute.
+func Fcpxml(raw []byte, _ uint32) bool {
+	raw = raw[:min(len(raw), 512)]
+	i := bytes.Index(raw, []byte("<fcpxml"))
+	if i == -1 {
+		return false
+	}
+	tag := raw[i+len("<fcpxml"):]
+	if end := bytes.IndexByte(tag, '>'); end != -1 {
+		tag = tag[:end]
+	}
+	// The root name must end here, as in <fcpxml version="1.11">.
+	if len(tag) == 0 || len(trimLWS(tag)) == len(tag) {
+		return false
+	}
+	for _, attr := range bytes.Fields(tag) {
+		if bytes.HasPrefix(attr, []byte("version=")) {
+			return true
+		}
+	}
+	return false
+}
+
+// Edl matches a CMX 3600 edit decision list. The list starts with a TITLE:
+// header and has events made of an event number, a reel, a track, a
+// transition and four timecodes, as in:
+//
+//	001  AX       V     C        00:00:00:00 00:00:05:00 01:00:00:00 01:00:05:00
+func Edl(raw []byte, limit uint32) bool {
+	if !bytes.HasPrefix(raw, []byte("TITLE:")) {
+		return false
+	}
+	raw = dropLastLine(raw, limit)
+	var l []byte
+	for len(raw) != 0 {
+		l, raw = scanLine(raw)
+		fields := bytes.Fields(l)
+		if len(fields) < 8 || !isDigits(fields[0]) {
+			continue
+		}
+		// Dissolves and wipes add a duration field after the transition.
+		tc := fields[len(fields)-4:]
+		if isTimecode(tc[0]) && isTimecode(tc[1]) && isTimecode(tc[2]) && isTimecode(tc[3]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isTimecode checks b is a SMPTE timecode like 01:00:05:00, or 01:00:05;00 for
+// drop frame timecodes.
+func isTimecode(b []byte) bool {
+	if len(b) != 11 {
+		return false
+	}
+	for i, c := range b {
+		switch i {
+		case 2, 5:
+			if c != ':' {
+				return false
+			}
+		case 8:
+			if c != ':' && c != ';' {
+				return false
+			}
+		default:
+			if c < '0' || c > '9' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ChromeTrace matches a trace in the JSON object format of the Chrome Trace
+// Event Format, an object with a "traceEvents" array.
+// Perfetto traces are protocol buffers without a magic number, so they are
+// not detected.
+// https://docs.google.com/document/d/1CvAClvFfyA5R-PhYUmn5OOQtYMH4h6I0nSsKchNAySU
+func ChromeTrace(raw []byte, _ uint32) bool {
+	raw = trimLWS(raw)
+	if len(raw) == 0 || raw[0] != '{' {
+		return false
+	}
+	i := bytes.Index(raw, []byte(`"traceEvents"`))
+	if i == -1 {
+		return false
+	}
+	raw = trimLWS(raw[i+len(`"traceEvents"`):])
+	if len(raw) == 0 || raw[0] != ':' {
+		return false
+	}
+	raw = trimLWS(raw[1:])
+	return len(raw) > 0 && raw[0] == '['
+}
+
+// FoldedStacks matches stack samples in the folded format used by the
+// FlameGraph tools: one stack per line, with the frames separated by ';' and
+// followed by a space and the sample count. Frames can themselves contain
+// spaces, so the count is after the last space of the line.
+// pprof profiles are gzip compressed protocol buffers, without a signature of
+// their own, so they are detected as gzip.
+// https://github.com/brendangregg/FlameGraph#2-fold-stacks
+func FoldedStacks(raw []byte, limit uint32) bool {
+	raw = dropLastLine(raw, limit)
+	lines, nested := 0, false
+	var l []byte
+	for len(raw) != 0 {
+		l, raw = scanLine(raw)
+		l = trimRWS(l)
+		if len(l) == 0 {
+			continue
+		}
+		i := bytes.LastIndexByte(l, ' ')
+		if i <= 0 || !isDigits(l[i+1:]) {
+			return false
+		}
+		frames := bytes.Split(l[:i], []byte(";"))
+		for _, f := range frames {
+			// Frame names are never empty nor padded,
+			// unlike clauses separated by ';' in prose.
+			if len(f) == 0 || f[0] == ' ' || f[len(f)-1] == ' ' {
+				return false
+			}
+		}
+		nested = nested || len(frames) > 1
+		lines++
+	}
+
+	return lines > 1 && nested
+}
+
+// Vmt matches a Valve Material Type file, the text describing a material of
+// the Source engine. The file starts with the name of a shader, optionally
+// quoted, followed by a block of key-value pairs whose keys are the shader
+// parameters, prefixed with '$'.
+// https://developer.valvesoftware.com/wiki/VMT
+func Vmt(raw []byte, limit uint32) bool {
+	raw = dropLastLine(raw, limit)
+	// state is 0 before the shader name, 1 before the block, and 2 in the block.
+	state := 0
+	var l []byte
+	for len(raw) != 0 {
+		l, raw = scanLine(raw)
+		l = trimRWS(trimLWS(l))
+		if len(l) == 0 || bytes.HasPrefix(l, []byte("//")) {
+			continue
+		}
+		switch state {
+		case 0:
+			if n := len(l); n > 2 && l[0] == '"' && l[n-1] == '"' {
+				l = l[1 : n-1]
+			}
+			if !isIdent(l) {
+				return false
+			}
+		case 1:
+			if l[0] != '{' {
+				return false
+			}
+		case 2:
+			// Only the first parameter is checked.
+			if l[0] == '"' {
+				l = l[1:]
+			}
+			if len(l) < 2 || l[0] != '$' {
+				return false
+			}
+			key := l[1:]
+			if i := bytes.IndexAny(key, "\" \t"); i != -1 {
+				key = key[:i]
+			}
+			return isIdent(key)
+		}
+		state++
+	}
+
+	return false
+}
+
+// Cwl matches a Common Workflow Language document, written either in YAML or
+// in JSON, with a cwlVersion key and a class naming the kind of process.
+// https://www.commonwl.org/v1.2/Workflow.html
+func Cwl(raw []byte, limit uint32) bool {
+	classes := [][]byte{
+		[]byte("Workflow"), []byte("CommandLineTool"),
+		[]byte("ExpressionTool"), []byte("Operation"),
+	}
+	if t := trimLWS(raw); len(t) > 0 && t[0] == '{' {
+		v := jsonStringValue(t, []byte(`"class"`))
+		if jsonStringValue(t, []byte(`"cwlVersion"`)) == nil || v == nil {
+			return false
+		}
+		for _, c := range classes {
+			if bytes.Equal(v, c) {
+				return true
+			}
+		}
+		return false
+	}
+
+	version, class := false, false
+	var l []byte
+	for len(raw) != 0 && !(version && class) {
+		l, raw = scanLine(raw)
+		// Keys must not be indented to be top level.
+		if k := []byte("cwlVersion:"); bytes.HasPrefix(l, k) {
+			version = len(trimLWS(l[len(k):])) > 0
+		} else if k := []byte("class:"); bytes.HasPrefix(l, k) {
+			v := trimRWS(trimLWS(l[len(k):]))
+			for _, c := range classes {
+				class = class || bytes.Equal(v, c)
+			}
+		}
+	}
+
+	return version && class
+}
+
+// jsonValue returns the input starting with the value of the first
+// occurrence of key in the JSON document raw, or nil if key is not found.
+func jsonValue(raw, key []byte) []byte {
+	i := bytes.Index(raw, key)
+	if i == -1 {
+		return nil
+	}
+	raw = trimLWS(raw[i+len(key):])
+	if len(raw) == 0 || raw[0] != ':' {
+		return nil
+	}
+	raw = trimLWS(raw[1:])
+	if len(raw) == 0 {
+		return nil
+	}
+	return raw
+}
+
+// jsonStringValue returns the string value of the first occurrence of key in
+// the JSON document raw, or nil if the key is not followed by a string.
+func jsonStringValue(raw, key []byte) []byte {
+	raw = jsonValue(raw, key)
+	if len(raw) == 0 || raw[0] != '"' {
+		return nil
+	}
+	v, _, ok := bytes.Cut(raw[1:], []byte(`"`))
+	if !ok {
+		return nil
+	}
+	return v
+}
+
+// Zeppelin matches an Apache Zeppelin notebook, a JSON object with an array
+// of paragraphs. The array is usually at the beginning of the object, so,
+// besides the angularObjects key of the notebook, the progressUpdateIntervalMs
+// key of a paragraph is also accepted as the second distinctive key.
+// https://zeppelin.apache.org/docs/latest/usage/other_features/zeppelin_notebook.html
+func Zeppelin(raw []byte, _ uint32) bool {
+	if t := trimLWS(raw); len(t) == 0 || t[0] != '{' {
+		return false
+	}
+	if v := jsonValue(raw, []byte(`"paragraphs"`)); len(v) == 0 || v[0] != '[' {
+		return false
+	}
+	if v := jsonValue(raw, []byte(`"angularObjects"`)); len(v) > 0 && v[0] == '{' {
+		return true
+	}
+	v := jsonValue(raw, []byte(`"progressUpdateIntervalMs"`))
+	return len(v) > 0 && '0' <= v[0] && v[0] <= '9'
+}
+
+// Nextflow matches a Nextflow pipeline script. The Groovy based language
+// defines process and workflow blocks, which pass data through channels.
+// Scripts must have one such block and a Channel factory call, unless they
+// start with a nextflow shebang.
+// https://www.nextflow.io/docs/latest/script.html
+func Nextflow(raw []byte, limit uint32) bool {
+	if bytes.HasPrefix(raw, []byte("#!/usr/bin/env nextflow")) {
+		return true
+	}
+	raw = dropLastLine(raw, limit)
+	block, channel := false, false
+	var l []byte
+	for len(raw) != 0 && !(block && channel) {
+		l, raw = scanLine(raw)
+		fields := bytes.Fields(l)
+		if len(fields) == 0 {
+			continue
+		}
+		switch {
+		case len(fields) == 3 && bytes.Equal(fields[0], []byte("process")):
+			block = block || isIdent(fields[1]) && bytes.Equal(fields[2], []byte("{"))
+		case bytes.Equal(fields[0], []byte("workflow")):
+			block = block || len(fields) == 2 && bytes.Equal(fields[1], []byte("{")) ||
+				len(fields) == 3 && isIdent(fields[1]) && bytes.Equal(fields[2], []byte("{"))
+		}
+		for _, c := range [][]byte{[]byte("Channel."), []byte("channel.")} {
+			if i := bytes.Index(l, c); i != -1 {
+				call := l[i+len(c):]
+				if j := bytes.IndexByte(call, '('); j > 0 && isIdent(call[:j]) {
+					channel = true
+				}
+			}
+		}
+	}
+
+	return block && channel
+}
+
+// Snakemake matches a Snakemake workflow. The language extends Python with
+// rule blocks, introduced by a "rule name:" or "checkpoint name:" line, and
+// having indented directives. At least one rule must have an input or output
+// directive.
+// https://snakemake.readthedocs.io/en/stable/snakefiles/rules.html
+func Snakemake(raw []byte, limit uint32) bool {
+	raw = dropLastLine(raw, limit)
+	inRule := false
+	var l []byte
+	for len(raw) != 0 {
+		l, raw = scanLine(raw)
+		if t := trimLWS(l); len(t) == 0 || t[0] == '#' {
+			continue
+		}
+		if l[0] != ' ' && l[0] != '\t' {
+			inRule = false
+			h := trimRWS(l)
+			if !bytes.HasSuffix(h, []byte(":")) {
+				continue
+			}
+			h = h[:len(h)-1]
+			for _, k := range [][]byte{[]byte("rule"), []byte("checkpoint")} {
+				if !bytes.HasPrefix(h, k) {
+					continue
+				}
+				// The rule name is optional.
+				name := h[len(k):]
+				inRule = len(name) == 0 || name[0] == ' ' && isIdent(trimLWS(name))
+			}
+			continue
+		}
+		if !inRule {
+			continue
+		}
+		d := trimRWS(trimLWS(l))
+		if bytes.HasPrefix(d, []byte("input:")) || bytes.HasPrefix(d, []byte("output:")) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // dropCR drops a terminal \r from the data.
 func dropCR(data []byte) []byte {
 	if len(data) > 0 && data[len(data)-1] == '\r' {
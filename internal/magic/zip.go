@@ -3,6 +3,7 @@ package magic
 import (
 	"bytes"
 	"encoding/binary"
+	"strings"
 )
 
 var (
@@ -24,14 +25,40 @@ var (
 	Otg = offset([]byte("mimetypeapplication/vnd.oasis.opendocument.graphics-template"), 30)
 	// Odf matches an OpenDocument Formula file.
 	Odf = offset([]byte("mimetypeapplication/vnd.oasis.opendocument.formula"), 30)
+	// OdfTemplate matches an OpenDocument Formula Template file.
+	OdfTemplate = offset([]byte("mimetypeapplication/vnd.oasis.opendocument.formula-template"), 30)
 	// Odc matches an OpenDocument Chart file.
 	Odc = offset([]byte("mimetypeapplication/vnd.oasis.opendocument.chart"), 30)
+	// Otc matches an OpenDocument Chart Template file.
+	Otc = offset([]byte("mimetypeapplication/vnd.oasis.opendocument.chart-template"), 30)
+	// Odi matches an OpenDocument Image file.
+	Odi = offset([]byte("mimetypeapplication/vnd.oasis.opendocument.image"), 30)
+	// Oti matches an OpenDocument Image Template file.
+	Oti = offset([]byte("mimetypeapplication/vnd.oasis.opendocument.image-template"), 30)
+	// Odm matches an OpenDocument Master Document file.
+	Odm = offset([]byte("mimetypeapplication/vnd.oasis.opendocument.text-master"), 30)
+	// Otm matches an OpenDocument Master Document Template file.
+	Otm = offset([]byte("mimetypeapplication/vnd.oasis.opendocument.text-master-template"), 30)
+	// Oth matches an OpenDocument HTML Template file.
+	Oth = offset([]byte("mimetypeapplication/vnd.oasis.opendocument.text-web"), 30)
 	// Epub matches an EPUB file.
 	Epub = offset([]byte("mimetypeapplication/epub+zip"), 30)
 	// Sxc matches an OpenOffice Spreadsheet file.
 	Sxc = offset([]byte("mimetypeapplication/vnd.sun.xml.calc"), 30)
+	// Kra matches a Krita document.
+	Kra = offset([]byte("mimetypeapplication/x-krita"), 30)
+	// Ora matches an OpenRaster image.
+	Ora = offset([]byte("mimetypeimage/openraster"), 30)
 )
 
+// Odb matches an OpenDocument Database file. LibreOffice writes the "base"
+// media type in the mimetype entry, while "database" is the registered one.
+func Odb(raw []byte, _ uint32) bool {
+	return len(raw) > 30 &&
+		(bytes.HasPrefix(raw[30:], []byte("mimetypeapplication/vnd.oasis.opendocument.base")) ||
+			bytes.HasPrefix(raw[30:], []byte("mimetypeapplication/vnd.oasis.opendocument.database")))
+}
+
 // Zip matches a zip archive.
 func Zip(raw []byte, limit uint32) bool {
 	return len(raw) > 3 &&
@@ -129,3 +156,116 @@ func APK(raw []byte, _ uint32) bool {
 
 	return false
 }
+
+// PkPass matches an Apple Wallet pass, a zip archive holding the pass.json
+// description, the manifest.json hashes and the signature of the manifest.
+// Passes contain images, so the signature entry can be far away in the file.
+// https://developer.apple.com/documentation/walletpasses/building-a-pass
+func PkPass(raw []byte, _ uint32) bool {
+	return zipHasAll(raw, "pass.json", "manifest.json", "signature")
+}
+
+// zipHasAll walks the local file headers of a zip archive and reports whether
+// all names are found among the entries. A name ending with a slash matches any
+// entry inside that directory.
+func zipHasAll(raw []byte, names ...string) bool {
+	found := 0
+	seen := make([]bool, len(names))
+	zipEach(raw, func(name string, _ []byte) bool {
+		for i, n := range names {
+			if !seen[i] && (name == n || n[len(n)-1] == '/' && strings.HasPrefix(name, n)) {
+				seen[i] = true
+				found++
+			}
+		}
+		return found < len(names)
+	})
+	return found == len(names)
+}
+
+// zipEach calls f with the name of each local file header of a zip archive,
+// and the input starting with that header, until f returns false. The walk
+// stops at the end of the input or at the first header which cannot be parsed.
+func zipEach(raw []byte, f func(name string, entry []byte) bool) {
+	pk := []byte("PK\003\004")
+	for o := 0; len(raw)-o >= 30 && bytes.HasPrefix(raw[o:], pk); {
+		flags := binary.LittleEndian.Uint16(raw[o+6:])
+		size := int(binary.LittleEndian.Uint32(raw[o+18:]))
+		nameLen := int(binary.LittleEndian.Uint16(raw[o+26:]))
+		extraLen := int(binary.LittleEndian.Uint16(raw[o+28:]))
+		if len(raw)-o-30 < nameLen {
+			return
+		}
+		if !f(string(raw[o+30:o+30+nameLen]), raw[o:]) {
+			return
+		}
+
+		next := o + 30 + nameLen + extraLen
+		if next > len(raw) {
+			return
+		}
+		// With a data descriptor, the size is unknown until after the data.
+		if flags&0x08 != 0 {
+			i := bytes.Index(raw[next:], pk)
+			if i == -1 {
+				return
+			}
+			next += i
+		} else {
+			next += size
+		}
+		if next <= o || next > len(raw) {
+			return
+		}
+		o = next
+	}
+}
+
+// Sketch matches a Sketch design document, a zip archive holding the
+// document.json and meta.json entries next to the JSON files of its pages.
+// https://developer.sketch.com/file-format/
+func Sketch(raw []byte, _ uint32) bool {
+	return zipHasAll(raw, "document.json", "meta.json")
+}
+
+// Fb3 matches a FictionBook 3 book. Like Office Open XML files, it is a zip
+// archive using Open Packaging Conventions, with a [Content_Types].xml entry,
+// but its parts are in the fb3 directory.
+// http://fictionbook.org/index.php/FB3
+func Fb3(raw []byte, _ uint32) bool {
+	return zipHasAll(raw, "[Content_Types].xml", "fb3/")
+}
+
+// AdobeXd matches an Adobe XD document, a zip archive holding a manifest next to
+// the interactions and resources directories.
+func AdobeXd(raw []byte, _ uint32) bool {
+	return zipHasAll(raw, "manifest", "interactions/", "resources/")
+}
+
+// Procreate matches a Procreate artwork, a zip archive with the
+// Document.archive binary property list describing the canvas.
+func Procreate(raw []byte, _ uint32) bool {
+	return zipHasAll(raw, "Document.archive")
+}
+
+// Dbc matches a Databricks archive, a zip archive of notebooks exported from a
+// workspace. Each notebook is a JSON file named after its language, as in
+// etl.python or report.sql.
+func Dbc(raw []byte, _ uint32) bool {
+	notebooks := 0
+	zipEach(raw, func(name string, _ []byte) bool {
+		if strings.HasSuffix(name, "/") {
+			return true
+		}
+		for _, ext := range []string{".python", ".scala", ".sql", ".r"} {
+			if strings.HasSuffix(name, ext) && len(name) > len(ext) {
+				notebooks++
+				return true
+			}
+		}
+		// Other kinds of entries mean it is not a Databricks archive.
+		notebooks = -1
+		return false
+	})
+	return notebooks > 0
+}
@@ -134,3 +134,32 @@ func TestZeroZip(t *testing.T) {
 		})
 	}
 }
+
+// The detectors walking the zip entries must not read past truncated headers.
+func TestZipEachTruncated(t *testing.T) {
+	detectors := map[string]Detector{
+		"pkpass": PkPass, "sketch": Sketch, "fb3": Fb3, "adobexd": AdobeXd,
+		"procreate": Procreate, "dbc": Dbc, "dotx": Dotx, "xltx": Xltx,
+		"potx": Potx, "ppsx": Ppsx,
+	}
+	inputs := [][]byte{
+		// Data descriptor flag, extra field past the input.
+		[]byte("PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x04\x00\xe8\x03a.js"),
+		// Name past the input.
+		[]byte("PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\xff\x00\x00\x00a.js"),
+	}
+	buf, err := createZip([]string{"[Content_Types].xml", "pass.json", "manifest.json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < buf.Len(); i++ {
+		inputs = append(inputs, buf.Bytes()[:i])
+	}
+	for name, d := range detectors {
+		for _, in := range inputs {
+			if d(in, 0) {
+				t.Errorf("%s: expected no match for %q", name, in)
+			}
+		}
+	}
+}
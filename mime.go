@@ -2,6 +2,7 @@ package mimetype
 
 import (
 	"mime"
+	"sync/atomic"
 
 	"github.com/gabriel-vasile/mimetype/internal/charset"
 	"github.com/gabriel-vasile/mimetype/internal/magic"
@@ -18,6 +19,25 @@ type MIME struct {
 	// detector receives the raw input and a limit for the number of bytes it is
 	// allowed to check. It returns whether the input matches a signature or not.
 	detector magic.Detector
+	// paramsFunc, when not nil, finds the optional MIME parameters of the
+	// input once the detector matched it.
+	paramsFunc magic.Params
+	// weakSig is true when the detector checks a signature which text files
+	// can contain by chance.
+	weakSig bool
+	// heuristicSig is true when the detector scans its input looking for a
+	// structure, rather than checking a signature at a fixed offset.
+	heuristicSig bool
+	// scanDefault, when not 0, bounds the input scanned by a heuristic
+	// detector when no heuristic scan limit is set.
+	scanDefault uint32
+	// readLimit overrides the global read limit for the detectors of this node
+	// and of its children when limitSet is true.
+	readLimit uint32
+	limitSet  bool
+	// rng, when not nil, is the part of the input checked by the detector.
+	// Without it, the detector is assumed to need the read limit.
+	rng      *byteRange
 	children []*MIME
 	parent   *MIME
 }
@@ -99,15 +119,82 @@ func (m *MIME) alias(aliases ...string) *MIME {
 	return m
 }
 
+func (m *MIME) withParams(f magic.Params) *MIME {
+	m.paramsFunc = f
+	return m
+}
+
+func (m *MIME) weak() *MIME {
+	m.weakSig = true
+	return m
+}
+
+func (m *MIME) heuristic() *MIME {
+	m.heuristicSig = true
+	return m
+}
+
+// scans marks the detector of m as heuristic, scanning at most n bytes of input
+// when no heuristic scan limit is set.
+func (m *MIME) scans(n uint32) *MIME {
+	m.heuristicSig, m.scanDefault = true, n
+	return m
+}
+
+// reads declares that the detector of m only checks length bytes at offset.
+func (m *MIME) reads(offset, length uint32) *MIME {
+	m.rng = &byteRange{offset: offset, length: length}
+	return m
+}
+
+// byteRange is the part of the input checked by a detector.
+type byteRange struct {
+	offset, length uint32
+}
+
+// end returns how many bytes of input are needed to cover r.
+func (r byteRange) end() uint32 {
+	return r.offset + r.length
+}
+
+// detect runs the detector of m on the first limit bytes of in. Heuristic
+// detectors are further limited to the heuristic scan limit.
+func (m *MIME) detect(in []byte, limit uint32) bool {
+	in = truncate(in, limit)
+	if m.heuristicSig {
+		// Using atomic because scanLimit can be written at the same time in other goroutine.
+		s := atomic.LoadUint32(&scanLimit)
+		if s == 0 {
+			s = m.scanDefault
+		}
+		if s > 0 && len(in) > int(s) {
+			in, limit = in[:s], s
+		}
+	}
+	return m.detector(in, limit)
+}
+
 // match does a depth-first search on the signature tree. It returns the deepest
 // successful node for which all the children detection functions fail.
+// The input can be longer than readLimit when some nodes have their own read
+// limit; each detector only receives the part of the input it is allowed to see.
 func (m *MIME) match(in []byte, readLimit uint32) *MIME {
 	for _, c := range m.children {
-		if c.detector(in, readLimit) {
-			return c.match(in, readLimit)
+		cLimit := c.limit(readLimit)
+		if c.detect(in, c.detectLimit(cLimit)) {
+			// Weak binary signatures at the root lose against text, if so configured.
+			if c.weakSig && m == root && atomic.LoadUint32(&preferText) == 1 {
+				tLimit := text.limit(readLimit)
+				if text.detect(in, tLimit) {
+					return text.match(in, tLimit)
+				}
+			}
+			return c.match(in, cLimit)
 		}
 	}
 
+	in = truncate(in, readLimit)
+
 	needsCharset := map[types.TYPE]func([]byte) string{
 		types.TEXT: charset.FromPlain,
 		types.HTML: charset.FromHTML,
@@ -120,10 +207,121 @@ func (m *MIME) match(in []byte, readLimit uint32) *MIME {
 			ps["charset"] = cset
 		}
 	}
+	if m.paramsFunc != nil {
+		for k, v := range m.paramsFunc(in, readLimit) {
+			ps[k] = v
+		}
+	}
 
 	return m.cloneHierarchy(ps)
 }
 
+// limit returns the read limit of m, given the read limit inherited from its parent.
+func (m *MIME) limit(inherited uint32) uint32 {
+	if m.limitSet {
+		return m.readLimit
+	}
+	return inherited
+}
+
+// detectLimit returns the number of bytes of input given to the detector of m,
+// given its read limit. Detectors declaring the part of the input they check
+// receive exactly that part, even when it goes past the read limit.
+func (m *MIME) detectLimit(limit uint32) uint32 {
+	if m.rng == nil || m.limitSet {
+		return limit
+	}
+	return m.rng.end()
+}
+
+// need is the part of the input needed by the detectors of a tree of MIME
+// types: the global read limit when global is true, and at least size bytes.
+// A size of wholeInput means the whole input is needed.
+type need struct {
+	global bool
+	size   uint32
+}
+
+func (n need) union(o need) need {
+	if o.size > n.size {
+		n.size = o.size
+	}
+	n.global = n.global || o.global
+	return n
+}
+
+// bytes returns how many bytes of input are needed when the global read limit
+// is l. A returned value of 0 means the whole input is needed.
+func (n need) bytes(l uint32) uint32 {
+	if n.size == wholeInput || n.global && l == 0 {
+		return 0
+	}
+	if n.global && l > n.size {
+		return l
+	}
+	return n.size
+}
+
+// need returns the part of the input needed by m and its sub-formats, given
+// what m inherits from its parent.
+func (m *MIME) need(inherited need) need {
+	if m.limitSet {
+		inherited = need{size: m.readLimit}
+		if m.readLimit == 0 {
+			inherited.size = wholeInput
+		}
+	}
+	n := inherited
+	if m.rng != nil && !m.limitSet {
+		r := need{size: m.rng.end()}
+		// The parameters of m, if any, are still found using the read limit.
+		if m.paramsFunc == nil {
+			n = r
+		} else {
+			n = n.union(r)
+		}
+	}
+	for _, c := range m.children {
+		n = n.union(c.need(inherited))
+	}
+	return n
+}
+
+// truncate returns the first limit bytes of in. A limit of 0 means no limit.
+func truncate(in []byte, limit uint32) []byte {
+	if limit > 0 && len(in) > int(limit) {
+		return in[:limit]
+	}
+	return in
+}
+
+// SetReadLimit sets the maximum number of bytes of input used by the detectors
+// of m and its sub-formats, overriding the limit set with SetLimit.
+// It is meant for formats which have their signature far away from the start of
+// the file, like DICOM at offset 128 or ISO 9660 at offset 32769: the input is
+// read up to the largest of all limits, but detectors of other formats still
+// only see the bytes allowed by the global limit.
+// A limit of 0 means the whole input file will be used.
+// Only the MIME types returned by Lookup are part of the detection tree.
+// Calling SetReadLimit on any other MIME, like the ones returned by Detect
+// functions or by SupportedMIMEs, silently does nothing.
+func (m *MIME) SetReadLimit(limit uint32) {
+	mu.Lock()
+	defer mu.Unlock()
+	m.readLimit, m.limitSet = limit, true
+	updateNeed()
+}
+
+// ResetReadLimit removes the limit set with SetReadLimit, so that m and its
+// sub-formats use the limit set with SetLimit again, or the one of a parent
+// format having its own limit.
+func (m *MIME) ResetReadLimit() {
+	mu.Lock()
+	defer mu.Unlock()
+	m.readLimit, m.limitSet = 0, false
+	updateNeed()
+}
+
 // flatten transforms an hierarchy of MIMEs into a slice of MIMEs.
 func (m *MIME) flatten() []*MIME {
 	out := []*MIME{m}
@@ -204,5 +402,6 @@ func (m *MIME) Extend(detector func(raw []byte, limit uint32) bool, mimestr, ext
 
 	mu.Lock()
 	m.children = append([]*MIME{c}, m.children...)
+	updateNeed()
 	mu.Unlock()
 }
@@ -17,6 +17,50 @@ var defaultLimit uint32 = 3072
 // readLimit is the maximum number of bytes from the input used when detecting.
 var readLimit uint32 = defaultLimit
 
+// treeNeed holds the part of the input needed by the detection tree, with the
+// size in the low 32 bits and the global flag in the high bits. It changes
+// when MIME types get their own read limit or when the tree is extended.
+var treeNeed = encodeNeed(root.need(need{global: true}))
+
+const wholeInput = ^uint32(0)
+
+func encodeNeed(n need) uint64 {
+	v := uint64(n.size)
+	if n.global {
+		v |= 1 << 32
+	}
+	return v
+}
+
+// updateNeed computes again the part of the input needed by the detection
+// tree. The caller must hold mu.
+func updateNeed() {
+	// Using atomic because treeNeed is read by Detect functions without locking mu.
+	atomic.StoreUint64(&treeNeed, encodeNeed(root.need(need{global: true})))
+}
+
+// inputLimit returns how many bytes of input are needed for detection, given
+// the global limit l. The detectors declaring the part of the input they check
+// and the MIME types having their own read limit can need more than l.
+// Text detection always needs l, and it is tried on every input, so only
+// growing the read past l is effective for the built-in tree: a read smaller
+// than l only happens in trees where every detector declares its range.
+// A returned value of 0 means the whole input is needed.
+func inputLimit(l uint32) uint32 {
+	v := atomic.LoadUint64(&treeNeed)
+	return need{global: v>>32 == 1, size: uint32(v)}.bytes(l)
+}
+
+// scanLimit is the maximum number of bytes scanned by heuristic detectors.
+// A value of 0 means they scan the whole read limit.
+var scanLimit uint32
+
+// preferText is 1 when text/plain is preferred over weak binary signatures.
+var preferText uint32
+
+// cookielessEXI is 1 when EXI streams without the "$EXI" cookie are detected.
+var cookielessEXI uint32
+
 // Detect returns the MIME type found from the provided byte slice.
 //
 // The result is always a valid MIME type, with application/octet-stream
@@ -24,9 +68,7 @@ var readLimit uint32 = defaultLimit
 func Detect(in []byte) *MIME {
 	// Using atomic because readLimit can be written at the same time in other goroutine.
 	l := atomic.LoadUint32(&readLimit)
-	if l > 0 && len(in) > int(l) {
-		in = in[:l]
-	}
+	in = truncate(in, inputLimit(l))
 	mu.RLock()
 	defer mu.RUnlock()
 	return root.match(in, l)
@@ -48,14 +90,14 @@ func DetectReader(r io.Reader) (*MIME, error) {
 
 	// Using atomic because readLimit can be written at the same time in other goroutine.
 	l := atomic.LoadUint32(&readLimit)
-	if l == 0 {
+	if size := inputLimit(l); size == 0 {
 		in, err = io.ReadAll(r)
 		if err != nil {
 			return errMIME, err
 		}
 	} else {
 		var n int
-		in = make([]byte, l)
+		in = make([]byte, size)
 		// io.UnexpectedEOF means len(r) < len(in). It is not an error in this case,
 		// it just means the input file is smaller than the allocated bytes slice.
 		n, err = io.ReadFull(r, in)
@@ -106,11 +148,55 @@ func EqualsAny(s string, mimes ...string) bool {
 // their magical numbers towards the end of the file: docx, pptx, xlsx, etc.
 // During detection data is read in a single block of size limit, i.e. it is not buffered.
 // A limit of 0 means the whole input file will be used.
+// Use MIME.SetReadLimit to change the limit only for some formats.
 func SetLimit(limit uint32) {
 	// Using atomic because readLimit can be read at the same time in other goroutine.
 	atomic.StoreUint32(&readLimit, limit)
 }
 
+// SetHeuristicScanLimit sets the maximum number of bytes scanned by each of
+// the detectors which look for a structure in the input instead of a
+// signature, like the CSV and TSV detectors, which parse records, or the
+// detectors for line based text formats. Those detectors can do a lot of work
+// on inputs which almost match, so limiting them bounds the detection time
+// when the read limit is large.
+// A limit of 0, the default, means they use the same limit as all detectors.
+func SetHeuristicScanLimit(limit uint32) {
+	// Using atomic because scanLimit can be read at the same time in other goroutine.
+	atomic.StoreUint32(&scanLimit, limit)
+}
+
+// SetPreferText sets which MIME type is detected when the input is text, but it
+// also begins with a weak binary signature. Weak signatures are short magic
+// numbers made of printable characters, e.g. "BM" for bitmap images or "MZ" for
+// Windows executables, which ordinary text can start with by chance.
+// When enabled, such inputs are detected as text/plain, or a text sub-format.
+// When disabled, the default, the binary format is detected.
+// Inputs containing binary data are never detected as text.
+func SetPreferText(enabled bool) {
+	var v uint32
+	if enabled {
+		v = 1
+	}
+	// Using atomic because preferText can be read at the same time in other goroutine.
+	atomic.StoreUint32(&preferText, v)
+}
+
+// SetCookielessEXI sets whether Efficient XML Interchange streams are detected
+// when they do not start with the optional "$EXI" cookie. Without the cookie,
+// such streams start with a single 0x80 byte, like Python pickle data and other
+// binary formats do, and only the name of their root element tells them apart.
+// When disabled, the default, only EXI streams starting with the cookie are
+// detected.
+func SetCookielessEXI(enabled bool) {
+	var v uint32
+	if enabled {
+		v = 1
+	}
+	// Using atomic because cookielessEXI can be read at the same time in other goroutine.
+	atomic.StoreUint32(&cookielessEXI, v)
+}
+
 // Extend adds detection for other file formats.
 // It is equivalent to calling Extend() on the root mime type "application/octet-stream".
 func Extend(detector func(raw []byte, limit uint32) bool, mime, extension string, aliases ...string) {
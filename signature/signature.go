@@ -0,0 +1,32 @@
+// Package signature has helpers for writing the detectors passed to
+// mimetype.Extend. All of them are safe to use on inputs of any length,
+// including empty and truncated inputs.
+package signature
+
+import "bytes"
+
+// MagicAt reports whether raw has signature at the given offset.
+// It returns false when raw is too short to hold the signature, or when offset
+// is negative.
+func MagicAt(raw []byte, offset int, signature []byte) bool {
+	if offset < 0 || offset > len(raw) || len(raw)-offset < len(signature) {
+		return false
+	}
+	return bytes.Equal(raw[offset:offset+len(signature)], signature)
+}
+
+// MagicPrefix reports whether raw starts with signature.
+func MagicPrefix(raw, signature []byte) bool {
+	return MagicAt(raw, 0, signature)
+}
+
+// MagicFooter reports whether raw ends with signature.
+// Detectors only receive the first limit bytes of the input, so the end of the
+// input is only known when limit is 0 or when raw is shorter than limit.
+// MagicFooter returns false when raw might have been truncated.
+func MagicFooter(raw []byte, limit uint32, signature []byte) bool {
+	if limit != 0 && uint32(len(raw)) >= limit {
+		return false
+	}
+	return MagicAt(raw, len(raw)-len(signature), signature)
+}
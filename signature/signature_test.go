@@ -0,0 +1,70 @@
+package signature
+
+import "testing"
+
+func TestMagicAt(t *testing.T) {
+	tcases := []struct {
+		name     string
+		raw      string
+		offset   int
+		sig      string
+		expected bool
+	}{
+		{"prefix", "DICM", 0, "DICM", true},
+		{"offset", "\x00\x00\x00\x00DICM", 4, "DICM", true},
+		{"offset mismatch", "\x00\x00\x00\x00DICX", 4, "DICM", false},
+		{"truncated", "\x00\x00\x00\x00DIC", 4, "DICM", false},
+		{"offset past end", "DICM", 10, "DICM", false},
+		{"negative offset", "DICM", -1, "DICM", false},
+		{"empty input", "", 0, "DICM", false},
+		{"nil input", "", 128, "DICM", false},
+		{"empty signature", "DICM", 4, "", true},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var raw []byte
+			if tc.raw != "" {
+				raw = []byte(tc.raw)
+			}
+			if got := MagicAt(raw, tc.offset, []byte(tc.sig)); got != tc.expected {
+				t.Errorf("expected: %t; got: %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestMagicPrefix(t *testing.T) {
+	if !MagicPrefix([]byte("foobar file"), []byte("foobar")) {
+		t.Errorf("prefix should match")
+	}
+	if MagicPrefix([]byte("foo"), []byte("foobar")) {
+		t.Errorf("short input should not match")
+	}
+	if MagicPrefix(nil, []byte("foobar")) {
+		t.Errorf("nil input should not match")
+	}
+}
+
+func TestMagicFooter(t *testing.T) {
+	tcases := []struct {
+		name     string
+		raw      string
+		limit    uint32
+		sig      string
+		expected bool
+	}{
+		{"whole input", "data koly", 0, "koly", true},
+		{"shorter than limit", "data koly", 100, "koly", true},
+		{"mismatch", "data kolx", 0, "koly", false},
+		{"possibly truncated", "data koly", 9, "koly", false},
+		{"short input", "oly", 0, "koly", false},
+		{"empty input", "", 0, "koly", false},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := MagicFooter([]byte(tc.raw), tc.limit, []byte(tc.sig)); got != tc.expected {
+				t.Errorf("expected: %t; got: %t", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+params.reads = "data/*_{1,2}.fq"
+
+process FASTQC {
+    input:
+    tuple val(sample), path(reads)
+
+    output:
+    path "fastqc_${sample}_logs"
+
+    script:
+    """
+    fastqc.sh "$sample" "$reads"
+    """
+}
+
+workflow {
+    reads_ch = Channel.fromFilePairs(params.reads, checkIfExists: true)
+    FASTQC(reads_ch)
+}
@@ -0,0 +1,19 @@
+configfile: "config.yaml"
+
+SAMPLES = ["A", "B"]
+
+
+rule all:
+    input:
+        expand("plots/{sample}.svg", sample=SAMPLES)
+
+
+rule bwa_map:
+    input:
+        "data/genome.fa",
+        "data/samples/{sample}.fastq"
+    output:
+        "mapped_reads/{sample}.bam"
+    threads: 8
+    shell:
+        "bwa mem -t {threads} {input} | samtools view -Sb - > {output}"
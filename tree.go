@@ -2,6 +2,7 @@ package mimetype
 
 import (
 	"sync"
+	"sync/atomic"
 
 	"github.com/gabriel-vasile/mimetype/internal/magic"
 	"github.com/gabriel-vasile/mimetype/types"
@@ -22,9 +23,13 @@ var root = newMIME(types.OCTET_STREAM, "",
 	jpm, jxs, gif, webp, exe, elf, ar, tar, xar, bz2, fits, tiff, bmp, ico, mp3,
 	flac, midi, ape, musePack, amr, wav, aiff, au, mpeg, quickTime, mp4, webM,
 	avi, flv, mkv, asf, aac, voc, m3u, rmvb, gzip, class, swf, crx, ttf, woff,
-	woff2, otf, ttc, eot, wasm, shx, dbf, dcm, rar, djvu, mobi, lit, bpg, cbor,
+	woff2, otf, ttc, eot, wasm, shx, dbf, dcm, rar, djvu, mobi, palmPdb, lit, bpg, fido, cbor,
 	sqlite3, dwg, nes, lnk, macho, qcp, icns, hdr, mrc, mdb, accdb, zstd, cab,
 	rpm, xz, lzip, torrent, cpio, tzif, xcf, pat, gbr, glb, cabIS, jxr, parquet,
+	exi, fastInfoset, icc, cupsRaster, figma, indesign, affinity, clipStudio, adobeAse, adobeAco, exr, mayaBinary, pgsSup, pbzx, bomStore, asar,
+	vpk, bsp, quakePak, doomWad, vtf,
+	// Keep weak, single byte signatures towards the end.
+	exiNoCookie, confluentWire, nbt,
 	// Keep text last because it is the slowest check.
 	text,
 )
@@ -39,7 +44,8 @@ var mu = &sync.RWMutex{}
 
 // The list of nodes appended to the root node.
 var (
-	xz   = newMIME(types.XZ, ".xz", magic.Xz)
+	xz = newMIME(types.XZ, ".xz", magic.Xz).
+		withParams(magic.XzParams)
 	gzip = newMIME(types.GZIP, ".gz", magic.Gzip).alias(
 		"application/x-gzip", "application/x-gunzip", "application/gzipped",
 		"application/gzip-compressed", "application/x-gzip-compressed",
@@ -49,24 +55,36 @@ var (
 	// This means APK should be a child of JAR detector, but in practice,
 	// the decisive signature for JAR might be located at the end of the file
 	// and not reachable because of library readLimit.
-	zip = newMIME(types.ZIP, ".zip", magic.Zip, xlsx, docx, pptx, epub, apk, jar, odt, ods, odp, odg, odf, odc, sxc).
+	zip = newMIME(types.ZIP, ".zip", magic.Zip, xlsx, docx, pptx, epub, apk, jar, odt, ods, odp, odg, odf, odc, odi, odb, sxc, kra, ora, pkPass, sketch, adobeXd, procreate, fb3, dbc).
 		alias("application/x-zip", "application/x-zip-compressed")
-	tar = newMIME(types.TAR, ".tar", magic.Tar)
-	xar = newMIME(types.XAR, ".xar", magic.Xar)
-	bz2 = newMIME(types.BZIP2, ".bz2", magic.Bz2)
+	pkPass    = newMIME(types.PKPASS, ".pkpass", magic.PkPass)
+	sketch    = newMIME(types.SKETCH, ".sketch", magic.Sketch)
+	fb3       = newMIME(types.FB3, ".fb3", magic.Fb3)
+	dbc       = newMIME(types.DBC, ".dbc", magic.Dbc)
+	procreate = newMIME(types.PROCREATE, ".procreate", magic.Procreate)
+	adobeXd   = newMIME(types.ADOBEXD, ".xd", magic.AdobeXd)
+	tar       = newMIME(types.TAR, ".tar", magic.Tar).reads(0, 512)
+	xar       = newMIME(types.XAR, ".xar", magic.Xar)
+	bz2       = newMIME(types.BZIP2, ".bz2", magic.Bz2).
+			withParams(magic.Bz2Params).
+			weak()
 	pdf = newMIME(types.PDF, ".pdf", magic.Pdf).
 		alias("application/x-pdf")
 	fdf  = newMIME(types.FDF, ".fdf", magic.Fdf)
-	xlsx = newMIME(types.XLSX, ".xlsx", magic.Xlsx)
-	docx = newMIME(types.DOCX, ".docx", magic.Docx)
-	pptx = newMIME(types.PPTX, ".pptx", magic.Pptx)
-	epub = newMIME(types.EPUB, ".epub", magic.Epub)
+	xlsx = newMIME(types.XLSX, ".xlsx", magic.Xlsx, xltx)
+	xltx = newMIME(types.XLTX, ".xltx", magic.Xltx)
+	docx = newMIME(types.DOCX, ".docx", magic.Docx, dotx)
+	dotx = newMIME(types.DOTX, ".dotx", magic.Dotx)
+	pptx = newMIME(types.PPTX, ".pptx", magic.Pptx, potx, ppsx)
+	potx = newMIME(types.POTX, ".potx", magic.Potx)
+	ppsx = newMIME(types.PPSX, ".ppsx", magic.Ppsx)
+	epub = newMIME(types.EPUB, ".epub", magic.Epub).reads(30, 28)
 	jar  = newMIME(types.JAR, ".jar", magic.Jar)
 	apk  = newMIME(types.APK, ".apk", magic.APK)
 	ole  = newMIME(types.OLE, "", magic.Ole, msi, aaf, msg, xls, pub, ppt, doc)
 	msi  = newMIME(types.MSI, ".msi", magic.Msi).
 		alias("application/x-windows-installer", "application/x-msi")
-	aaf = newMIME(types.OCTET_STREAM, ".aaf", magic.Aaf)
+	aaf = newMIME(types.AAF, ".aaf", magic.Aaf)
 	doc = newMIME(types.DOC, ".doc", magic.Doc).
 		alias("application/vnd.ms-word")
 	ppt = newMIME(types.PPT, ".ppt", magic.Ppt).
@@ -81,23 +99,27 @@ var (
 		alias("application/x-ogg")
 	oggAudio = newMIME(types.OGGAUDIO, ".oga", magic.OggAudio)
 	oggVideo = newMIME(types.OGGVIDEO, ".ogv", magic.OggVideo)
-	text     = newMIME(types.TEXT, ".txt", magic.Text, html, svg, xml, php, js, lua, perl, python, json, ndJSON, rtf, srt, tcl, csv, tsv, vCard, iCalendar, warc, vtt)
-	xml      = newMIME(types.XML, ".xml", magic.XML, rss, atom, x3d, kml, xliff, collada, gml, gpx, tcx, amf, threemf, xfdf, owl2).
+	text     = newMIME(types.TEXT, ".txt", magic.Text, html, svg, xml, php, js, lua, perl, python, json, ndJSON, rtf, srt, tcl, influxLine, csv, tsv, vCard, iCalendar, warc, vtt, dnsZone, sshKnownHosts, sshAuthorizedKeys, jwt, jwe, cborDiag, cubeLut, ocio, ppd, kicad, gerber, gcode, vhdl, verilog, spice, openScad, openMetrics, graphite, gimpPalette, mayaAscii, nuke, vobSubIdx, edl, foldedStacks, vmt, cwl, nextflow, snakemake)
+	xml      = newMIME(types.XML, ".xml", magic.XML, rss, atom, x3d, kml, xliff, collada, gml, gpx, tcx, amf, threemf, xfdf, owl2, soap, wsdl, wadl, saml, xmlDsig, fcpxml).
 			alias("application/xml")
-	json    = newMIME(types.JSON, ".json", magic.JSON, geoJSON, har)
-	har     = newMIME(types.JSON, ".har", magic.HAR)
-	csv     = newMIME(types.CSV, ".csv", magic.Csv)
-	tsv     = newMIME(types.TSV, ".tsv", magic.Tsv)
-	geoJSON = newMIME(types.GEOJSON, ".geojson", magic.GeoJSON)
-	ndJSON  = newMIME(types.NDJSON, ".ndjson", magic.NdJSON)
-	html    = newMIME(types.HTML, ".html", magic.HTML)
-	php     = newMIME(types.PHP, ".php", magic.Php)
-	rtf     = newMIME(types.RTF, ".rtf", magic.Rtf).alias("application/rtf")
-	js      = newMIME(types.JS, ".js", magic.Js).
-		alias("application/x-javascript", "application/javascript")
+	json        = newMIME(types.JSON, ".json", magic.JSON, geoJSON, har, chromeTrace, cwlJSON, zeppelin)
+	har         = newMIME(types.JSON, ".har", magic.HAR)
+	chromeTrace = newMIME(types.CHROMETRACE, ".trace", magic.ChromeTrace)
+	// cwlJSON has the same MIME as cwl, for documents written in JSON.
+	cwlJSON  = newMIME(types.CWL, ".cwl", magic.Cwl)
+	zeppelin = newMIME(types.ZEPPELIN, ".json", magic.Zeppelin)
+	csv      = newMIME(types.CSV, ".csv", magic.Csv).heuristic()
+	tsv      = newMIME(types.TSV, ".tsv", magic.Tsv).heuristic()
+	geoJSON  = newMIME(types.GEOJSON, ".geojson", magic.GeoJSON)
+	ndJSON   = newMIME(types.NDJSON, ".ndjson", magic.NdJSON).heuristic()
+	html     = newMIME(types.HTML, ".html", magic.HTML)
+	php      = newMIME(types.PHP, ".php", magic.Php)
+	rtf      = newMIME(types.RTF, ".rtf", magic.Rtf).alias("application/rtf")
+	js       = newMIME(types.JS, ".js", magic.Js).
+			alias("application/x-javascript", "application/javascript")
 	srt = newMIME(types.SRT, ".srt", magic.Srt).
-		alias("application/x-srt", "text/x-srt")
-	vtt    = newMIME(types.VTT, ".vtt", magic.Vtt)
+		alias("application/x-srt", "text/x-srt").heuristic()
+	vtt    = newMIME(types.VTT, ".vtt", magic.Vtt).heuristic()
 	lua    = newMIME(types.LUA, ".lua", magic.Lua)
 	perl   = newMIME(types.PERL, ".pl", magic.Perl)
 	python = newMIME(types.PYTHON, ".py", magic.Python).
@@ -121,7 +143,7 @@ var (
 	amf     = newMIME(types.AMF, ".amf", magic.Amf)
 	threemf = newMIME(types.THREEMF, ".3mf", magic.Threemf)
 	png     = newMIME(types.PNG, ".png", magic.Png, apng)
-	apng    = newMIME(types.APNG, ".png", magic.Apng)
+	apng    = newMIME(types.APNG, ".png", magic.Apng).reads(37, 4)
 	jpg     = newMIME(types.JPG, ".jpg", magic.Jpg)
 	jxl     = newMIME(types.JXL, ".jxl", magic.Jxl)
 	jp2     = newMIME(types.JP2, ".jp2", magic.Jp2)
@@ -132,35 +154,39 @@ var (
 	xpm  = newMIME(types.XPM, ".xpm", magic.Xpm)
 	bpg  = newMIME(types.BPG, ".bpg", magic.Bpg)
 	gif  = newMIME("image/gif", ".gif", magic.Gif)
-	webp = newMIME(types.WEBP, ".webp", magic.Webp)
+	webp = newMIME(types.WEBP, ".webp", magic.Webp).
+		withParams(magic.WebpParams)
 	tiff = newMIME(types.TIFF, ".tiff", magic.Tiff)
 	bmp  = newMIME(types.BMP, ".bmp", magic.Bmp).
-		alias("image/x-bmp", "image/x-ms-bmp")
+		alias("image/x-bmp", "image/x-ms-bmp").weak()
 	ico  = newMIME(types.ICO, ".ico", magic.Ico)
 	icns = newMIME(types.ICNS, ".icns", magic.Icns)
 	psd  = newMIME(types.PSD, ".psd", magic.Psd).
 		alias("image/x-psd", "application/photoshop")
-	heic    = newMIME(types.HEIC, ".heic", magic.Heic)
+	heic = newMIME(types.HEIC, ".heic", magic.Heic).
+		withParams(magic.HeifParams)
 	heicSeq = newMIME(types.HEICSEQ, ".heic", magic.HeicSequence)
-	heif    = newMIME(types.HEIF, ".heif", magic.Heif)
+	heif    = newMIME(types.HEIF, ".heif", magic.Heif).
+		withParams(magic.HeifParams)
 	heifSeq = newMIME(types.HEIFSEQ, ".heif", magic.HeifSequence)
 	hdr     = newMIME(types.HDR, ".hdr", magic.Hdr)
 	avif    = newMIME(types.AVIF, ".avif", magic.AVIF)
 	mp3     = newMIME(types.MP3, ".mp3", magic.Mp3).
-		alias("audio/x-mpeg", "audio/mp3")
+		alias("audio/x-mpeg", "audio/mp3").weak()
 	flac = newMIME(types.FLAC, ".flac", magic.Flac)
 	midi = newMIME(types.MIDI, ".midi", magic.Midi).
 		alias("audio/mid", "audio/sp-midi", "audio/x-mid", "audio/x-midi")
 	ape      = newMIME(types.APE, ".ape", magic.Ape)
 	musePack = newMIME(types.MUSEPACK, ".mpc", magic.MusePack)
 	wav      = newMIME(types.WAV, ".wav", magic.Wav).
-			alias("audio/x-wav", "audio/vnd.wave", "audio/wave")
+			alias("audio/x-wav", "audio/vnd.wave", "audio/wave").
+			withParams(magic.WavParams)
 	aiff = newMIME(types.AIFF, ".aiff", magic.Aiff).
 		alias("audio/x-aiff")
 	au  = newMIME(types.AU, ".au", magic.Au)
 	amr = newMIME(types.AMR, ".amr", magic.Amr).
 		alias("audio/amr-nb")
-	aac  = newMIME(types.AAC, ".aac", magic.AAC)
+	aac  = newMIME(types.AAC, ".aac", magic.AAC).weak()
 	voc  = newMIME(types.VOC, ".voc", magic.Voc)
 	aMp4 = newMIME(types.AMP4, ".mp4", magic.AMp4).
 		alias("audio/x-mp4a")
@@ -188,12 +214,13 @@ var (
 		alias("video/asf", "video/x-ms-wmv")
 	rmvb  = newMIME(types.RMVB, ".rmvb", magic.Rmvb)
 	class = newMIME(types.CLASS, ".class", magic.Class)
-	swf   = newMIME(types.SWF, ".swf", magic.SWF)
+	swf   = newMIME(types.SWF, ".swf", magic.SWF).weak()
 	crx   = newMIME(types.CRX, ".crx", magic.CRX)
 	ttf   = newMIME(types.TTF, ".ttf", magic.Ttf).
 		alias("font/sfnt", "application/x-font-ttf", "application/font-sfnt")
-	woff    = newMIME(types.WOFF, ".woff", magic.Woff)
-	woff2   = newMIME(types.WOFF2, ".woff2", magic.Woff2)
+	woff  = newMIME(types.WOFF, ".woff", magic.Woff)
+	woff2 = newMIME(types.WOFF2, ".woff2", magic.Woff2).
+		withParams(magic.Woff2Params)
 	otf     = newMIME(types.OTF, ".otf", magic.Otf)
 	ttc     = newMIME(types.TTC, ".ttc", magic.Ttc)
 	eot     = newMIME(types.EOT, ".eot", magic.Eot)
@@ -201,41 +228,64 @@ var (
 	shp     = newMIME(types.SHP, ".shp", magic.Shp)
 	shx     = newMIME(types.SHX, ".shx", magic.Shx, shp)
 	dbf     = newMIME(types.DBF, ".dbf", magic.Dbf)
-	exe     = newMIME(types.EXE, ".exe", magic.Exe)
-	elf     = newMIME(types.ELF, "", magic.Elf, elfObj, elfExe, elfLib, elfDump)
+	exe     = newMIME(types.EXE, ".exe", magic.Exe, nodeSea(".exe"), deno(".exe")).weak()
+	elf     = newMIME(types.ELF, "", magic.Elf, nodeSea(""), deno(""), elfObj, elfExe, elfLib, elfDump)
 	elfObj  = newMIME(types.ELFOBJ, "", magic.ElfObj)
 	elfExe  = newMIME(types.ELFEXE, "", magic.ElfExe)
 	elfLib  = newMIME(types.ELFLIB, ".so", magic.ElfLib)
 	elfDump = newMIME(types.ELFDUMP, "", magic.ElfDump)
-	ar      = newMIME(types.AR, ".a", magic.Ar, deb).
+	ar      = newMIME(types.AR, ".a", magic.Ar, deb).reads(0, 7).
 		alias("application/x-unix-archive")
-	deb = newMIME(types.DEB, ".deb", magic.Deb)
+	deb = newMIME(types.DEB, ".deb", magic.Deb).reads(8, 13)
 	rpm = newMIME(types.RPM, ".rpm", magic.RPM)
-	dcm = newMIME(types.DCM, ".dcm", magic.Dcm)
-	odt = newMIME(types.ODT, ".odt", magic.Odt, ott).
+	dcm = newMIME(types.DCM, ".dcm", magic.Dcm).reads(128, 4)
+	odt = newMIME(types.ODT, ".odt", magic.Odt, ott, odm, oth).reads(30, 47).
 		alias("application/x-vnd.oasis.opendocument.text")
-	ott = newMIME(types.OTT, ".ott", magic.Ott).
+	ott = newMIME(types.OTT, ".ott", magic.Ott).reads(30, 56).
 		alias("application/x-vnd.oasis.opendocument.text-template")
-	ods = newMIME(types.ODS, ".ods", magic.Ods, ots).
+	ods = newMIME(types.ODS, ".ods", magic.Ods, ots).reads(30, 54).
 		alias("application/x-vnd.oasis.opendocument.spreadsheet")
-	ots = newMIME(types.OTS, ".ots", magic.Ots).
+	ots = newMIME(types.OTS, ".ots", magic.Ots).reads(30, 63).
 		alias("application/x-vnd.oasis.opendocument.spreadsheet-template")
-	odp = newMIME(types.ODP, ".odp", magic.Odp, otp).
+	odp = newMIME(types.ODP, ".odp", magic.Odp, otp).reads(30, 55).
 		alias("application/x-vnd.oasis.opendocument.presentation")
-	otp = newMIME(types.OTP, ".otp", magic.Otp).
+	otp = newMIME(types.OTP, ".otp", magic.Otp).reads(30, 64).
 		alias("application/x-vnd.oasis.opendocument.presentation-template")
-	odg = newMIME(types.ODG, ".odg", magic.Odg, otg).
+	odg = newMIME(types.ODG, ".odg", magic.Odg, otg).reads(30, 51).
 		alias("application/x-vnd.oasis.opendocument.graphics")
-	otg = newMIME(types.OTG, ".otg", magic.Otg).alias("application/x-vnd.oasis.opendocument.graphics-template")
-	odf = newMIME(types.ODF, ".odf", magic.Odf).
+	otg = newMIME(types.OTG, ".otg", magic.Otg).reads(30, 60).alias("application/x-vnd.oasis.opendocument.graphics-template")
+	odm = newMIME(types.ODM, ".odm", magic.Odm, otm).reads(30, 54)
+	otm = newMIME(types.OTM, ".otm", magic.Otm).reads(30, 63)
+	oth = newMIME(types.OTH, ".oth", magic.Oth).reads(30, 51)
+	odf = newMIME(types.ODF, ".odf", magic.Odf, odfTemplate).reads(30, 50).
 		alias("application/x-vnd.oasis.opendocument.formula")
-	odc = newMIME(types.ODC, ".odc", magic.Odc).
-		alias("application/x-vnd.oasis.opendocument.chart")
-	sxc = newMIME(types.SXC, ".sxc", magic.Sxc)
+	odfTemplate = newMIME(types.ODFTEMPLATE, ".otf", magic.OdfTemplate).reads(30, 59)
+	odc         = newMIME(types.ODC, ".odc", magic.Odc, otc).reads(30, 48).
+			alias("application/x-vnd.oasis.opendocument.chart")
+	otc = newMIME(types.OTC, ".otc", magic.Otc).reads(30, 57)
+	odi = newMIME(types.ODI, ".odi", magic.Odi, oti).reads(30, 48)
+	oti = newMIME(types.OTI, ".oti", magic.Oti).reads(30, 57)
+	odb = newMIME(types.ODB, ".odb", magic.Odb).reads(30, 51).
+		alias("application/vnd.oasis.opendocument.base")
+	sxc = newMIME(types.SXC, ".sxc", magic.Sxc).reads(30, 36)
+	kra = newMIME(types.KRA, ".kra", magic.Kra).reads(30, 27)
+	ora = newMIME(types.ORA, ".ora", magic.Ora).reads(30, 24)
 	rar = newMIME(types.RAR, ".rar", magic.RAR).
 		alias("application/x-rar")
-	djvu    = newMIME(types.DJVU, ".djvu", magic.DjVu)
-	mobi    = newMIME(types.MOBI, ".mobi", magic.Mobi)
+	djvu = newMIME(types.DJVU, ".djvu", magic.DjVu)
+	// mobi is checked before palmPdb, on its own, because many Mobi files only
+	// carry the BOOKMOBI signature and not a valid Palm database header.
+	mobi = newMIME(types.MOBI, ".mobi", magic.Mobi, azw3).reads(60, 8).
+		withParams(magic.PalmPdbParams)
+	palmPdb = newMIME(types.PALMPDB, ".pdb", magic.PalmPdb, palmDoc, plucker, eReader).
+		withParams(magic.PalmPdbParams)
+	palmDoc = newMIME(types.PALMDOC, ".pdb", magic.PalmDoc).reads(60, 8).
+		withParams(magic.PalmPdbParams)
+	plucker = newMIME(types.PLUCKER, ".pdb", magic.Plucker).reads(60, 8).
+		withParams(magic.PalmPdbParams)
+	eReader = newMIME(types.EREADER, ".pdb", magic.EReader).reads(60, 8).
+		withParams(magic.PalmPdbParams)
+	azw3    = newMIME(types.AZW3, ".azw3", magic.Azw3)
 	lit     = newMIME(types.LIT, ".lit", magic.Lit)
 	sqlite3 = newMIME(types.SQLITE3, ".sqlite", magic.Sqlite).
 		alias("application/x-sqlite3")
@@ -243,26 +293,37 @@ var (
 		alias("image/x-dwg", "application/acad", "application/x-acad",
 			"application/autocad_dwg", "application/dwg", "application/x-dwg",
 			"application/x-autocad", "drawing/dwg")
-	warc  = newMIME(types.WARC, ".warc", magic.Warc)
-	nes   = newMIME(types.NES, ".nes", magic.Nes)
-	lnk   = newMIME(types.LNK, ".lnk", magic.Lnk)
-	macho = newMIME(types.MACHO, ".macho", magic.MachO)
-	qcp   = newMIME(types.QCP, ".qcp", magic.Qcp)
-	mrc   = newMIME(types.MRC, ".mrc", magic.Marc)
-	mdb   = newMIME(types.MDB, ".mdb", magic.MsAccessMdb)
-	accdb = newMIME(types.ACCDB, ".accdb", magic.MsAccessAce)
-	zstd  = newMIME(types.ZSTD, ".zst", magic.Zstd)
+	warc     = newMIME(types.WARC, ".warc", magic.Warc)
+	nes      = newMIME(types.NES, ".nes", magic.Nes)
+	lnk      = newMIME(types.LNK, ".lnk", magic.Lnk)
+	macho    = newMIME(types.MACHO, ".macho", magic.MachO, nodeSea(""), deno(""))
+	qcp      = newMIME(types.QCP, ".qcp", magic.Qcp)
+	mrc      = newMIME(types.MRC, ".mrc", magic.Marc)
+	mdb      = newMIME(types.MDB, ".mdb", magic.MsAccessMdb).reads(4, 15)
+	accdb    = newMIME(types.ACCDB, ".accdb", magic.MsAccessAce).reads(4, 15)
+	pbzx     = newMIME(types.PBZX, ".pbzx", magic.Pbzx)
+	bomStore = newMIME(types.BOM, ".car", magic.BomStore)
+	asar     = newMIME(types.ASAR, ".asar", magic.Asar)
+	vpk      = newMIME(types.VPK, ".vpk", magic.Vpk)
+	bsp      = newMIME(types.BSP, ".bsp", magic.Bsp)
+	quakePak = newMIME(types.QUAKEPAK, ".pak", magic.QuakePak)
+	doomWad  = newMIME(types.DOOMWAD, ".wad", magic.DoomWad)
+	vtf      = newMIME(types.VTF, ".vtf", magic.Vtf)
+	nbt      = newMIME(types.NBT, ".nbt", magic.Nbt)
+	zstd     = newMIME(types.ZSTD, ".zst", magic.Zstd).
+			withParams(magic.ZstdParams)
 	cab   = newMIME(types.CAB, ".cab", magic.Cab)
 	cabIS = newMIME(types.CABIS, ".cab", magic.InstallShieldCab)
 	lzip  = newMIME(types.LZIP, ".lz", magic.Lzip).
 		alias("application/x-lzip")
 	torrent = newMIME(types.TORRENT, ".torrent", magic.Torrent)
-	cpio    = newMIME(types.CPIO, ".cpio", magic.Cpio)
+	cpio    = newMIME(types.CPIO, ".cpio", magic.Cpio, houdini).weak()
+	houdini = newMIME(types.HOUDINI, ".hip", magic.HoudiniHip)
 	tzif    = newMIME(types.TZIF, "", magic.TzIf)
 	p7s     = newMIME(types.P7S, ".p7s", magic.P7s)
 	xcf     = newMIME(types.XCF, ".xcf", magic.Xcf)
-	pat     = newMIME(types.PAT, ".pat", magic.Pat)
-	gbr     = newMIME(types.GBR, ".gbr", magic.Gbr)
+	pat     = newMIME(types.PAT, ".pat", magic.Pat).reads(20, 4)
+	gbr     = newMIME(types.GBR, ".gbr", magic.Gbr).reads(20, 4)
 	xfdf    = newMIME(types.XFDF, ".xfdf", magic.Xfdf)
 	glb     = newMIME(types.GLB, ".glb", magic.Glb)
 	jxr     = newMIME(types.JXR, ".jxr", magic.Jxr).
@@ -270,4 +331,87 @@ var (
 	parquet = newMIME(types.PARQUET, ".parquet", magic.Par1).
 		alias("application/x-parquet")
 	cbor = newMIME(types.CBOR, ".cbor", magic.CBOR)
+	// fido is a root node, checked before cbor, because WebAuthn clients send
+	// attestation objects without the CBOR self-described tag cbor looks for.
+	// Objects with the tag are matched too.
+	fido = newMIME(types.FIDOATTEST, "", magic.FidoAttestation)
+	exi  = newMIME(types.EXI, ".exi", magic.Exi)
+	// exiNoCookie has the same MIME as exi, but a much weaker signature, so
+	// it is only tried when enabled with SetCookielessEXI.
+	exiNoCookie = newMIME(types.EXI, ".exi", func(raw []byte, limit uint32) bool {
+		return atomic.LoadUint32(&cookielessEXI) == 1 && magic.ExiNoCookie(raw, limit)
+	})
+	confluentWire = newMIME(types.CONFLUENT, "", magic.ConfluentWire).
+			withParams(magic.ConfluentWireParams).
+			weak()
+	fastInfoset = newMIME(types.FASTINFOSET, ".finf", magic.FastInfoset)
+	icc         = newMIME(types.ICC, ".icc", magic.IccProfile)
+	dnsZone     = newMIME(types.DNSZONE, ".zone", magic.DNSZone).
+			alias("text/x-zonefile").heuristic()
+	sshKnownHosts     = newMIME(types.SSHKNOWNHOST, "", magic.SSHKnownHosts).heuristic()
+	sshAuthorizedKeys = newMIME(types.SSHAUTHKEYS, "", magic.SSHAuthorizedKeys).heuristic()
+	jwt               = newMIME(types.JWT, "", magic.Jwt)
+	jwe               = newMIME(types.JOSE, "", magic.Jwe)
+	cborDiag          = newMIME(types.CBORDIAG, ".edn", magic.CborDiagnostic)
+	cubeLut           = newMIME(types.CUBELUT, ".cube", magic.CubeLut).heuristic()
+	ocio              = newMIME(types.OCIO, ".ocio", magic.OcioConfig)
+	ppd               = newMIME(types.PPD, ".ppd", magic.Ppd)
+	gcode             = newMIME(types.GCODE, ".gcode", magic.Gcode).
+				alias("application/x-gcode").heuristic()
+	kicad       = newMIME(types.KICAD, ".kicad_pcb", magic.KiCad).heuristic()
+	gerber      = newMIME(types.GERBER, ".gbr", magic.Gerber).heuristic()
+	vhdl        = newMIME(types.VHDL, ".vhd", magic.Vhdl).heuristic()
+	verilog     = newMIME(types.VERILOG, ".v", magic.Verilog).heuristic()
+	spice       = newMIME(types.SPICE, ".cir", magic.Spice).heuristic()
+	openScad    = newMIME(types.OPENSCAD, ".scad", magic.OpenScad).heuristic()
+	openMetrics = newMIME(types.OPENMETRICS, ".prom", magic.OpenMetrics).heuristic()
+	// influxLine is checked before csv because line protocol records contain commas.
+	influxLine   = newMIME(types.INFLUXLINE, "", magic.InfluxLine).heuristic()
+	gimpPalette  = newMIME(types.GIMPPALETTE, ".gpl", magic.GimpPalette)
+	graphite     = newMIME(types.GRAPHITE, "", magic.Graphite).heuristic()
+	foldedStacks = newMIME(types.FOLDEDSTACKS, ".folded", magic.FoldedStacks).heuristic()
+	vmt          = newMIME(types.VMT, ".vmt", magic.Vmt).heuristic()
+	cwl          = newMIME(types.CWL, ".cwl", magic.Cwl).heuristic()
+	nextflow     = newMIME(types.NEXTFLOW, ".nf", magic.Nextflow).heuristic()
+	snakemake    = newMIME(types.SNAKEMAKE, ".smk", magic.Snakemake).heuristic()
+	indesign     = newMIME(types.INDESIGN, ".indd", magic.Indesign)
+	clipStudio   = newMIME(types.CLIPSTUDIO, ".clip", magic.ClipStudio)
+	pgsSup       = newMIME(types.PGS, ".sup", magic.PgsSup)
+	vobSubIdx    = newMIME(types.VOBSUBIDX, ".idx", magic.VobSubIdx)
+	edl          = newMIME(types.EDL, ".edl", magic.Edl).heuristic()
+	mayaBinary   = newMIME(types.MAYABINARY, ".mb", magic.MayaBinary)
+	mayaAscii    = newMIME(types.MAYAASCII, ".ma", magic.MayaAscii)
+	nuke         = newMIME(types.NUKE, ".nk", magic.NukeScript).heuristic()
+	exr          = newMIME(types.EXR, ".exr", magic.Exr).
+			withParams(magic.ExrParams)
+	adobeAse   = newMIME(types.ADOBEASE, ".ase", magic.AdobeAse)
+	adobeAco   = newMIME(types.ADOBEACO, ".aco", magic.AdobeAco)
+	affinity   = newMIME(types.AFFINITY, ".afdesign", magic.Affinity)
+	figma      = newMIME(types.FIGMA, ".fig", magic.FigmaFig)
+	cupsRaster = newMIME(types.CUPSRASTER, "", magic.CupsRaster)
+	fcpxml     = newMIME(types.FCPXML, ".fcpxml", magic.Fcpxml)
+	soap       = newMIME(types.SOAP, "", magic.Soap)
+	wsdl       = newMIME(types.WSDL, ".wsdl", magic.Wsdl)
+	wadl       = newMIME(types.WADL, ".wadl", magic.Wadl)
+	saml       = newMIME(types.SAML, ".saml", magic.Saml)
+	// xmlDsig must come after saml because SAML responses are usually signed.
+	xmlDsig = newMIME(types.XMLDSIG, ".xml", magic.XmlDsig)
 )
+
+// nodeSea returns the node of Node.js single executable applications, which
+// are built for each executable format. Every one of exe, elf and macho gets
+// its own copy of the node, because a node has a single parent: detected
+// results keep exe, elf or macho as their parent, and the executable header is
+// checked before scanning for the fuse. The copies share the MIME type and
+// only differ by extension, so supported_mimes.md lists them once.
+func nodeSea(extension string) *MIME {
+	// The fuse is usually far from the start of the binary. Scanning for it is
+	// bounded to 1MB, unless a heuristic scan limit is set.
+	return newMIME(types.NODESEA, extension, magic.NodeSea).scans(1 << 20)
+}
+
+// deno returns the node of Deno compiled programs, built for each executable
+// format like the Node.js single executable applications.
+func deno(extension string) *MIME {
+	return newMIME(types.DENO, extension, magic.DenoCompiled)
+}
@@ -178,4 +178,94 @@ const (
 	JXR          TYPE = "image/jxr"
 	PARQUET      TYPE = "application/vnd.apache.parquet"
 	CBOR         TYPE = "application/cbor"
+	SOAP         TYPE = "application/soap+xml"
+	WSDL         TYPE = "application/wsdl+xml"
+	WADL         TYPE = "application/vnd.sun.wadl+xml"
+	SAML         TYPE = "application/samlassertion+xml"
+	XMLDSIG      TYPE = "application/x-xmldsig+xml"
+	EXI          TYPE = "application/exi"
+	FASTINFOSET  TYPE = "application/fastinfoset"
+	DNSZONE      TYPE = "text/dns"
+	SSHAUTHKEYS  TYPE = "text/x-ssh-authorized-keys"
+	SSHKNOWNHOST TYPE = "text/x-ssh-known-hosts"
+	JWT          TYPE = "application/jwt"
+	JOSE         TYPE = "application/jose"
+	CBORDIAG     TYPE = "application/cbor-diagnostic"
+	ICC          TYPE = "application/vnd.iccprofile"
+	CUBELUT      TYPE = "text/x-cube-lut"
+	OCIO         TYPE = "application/x-ocio-config"
+	PPD          TYPE = "application/vnd.cups-ppd"
+	CUPSRASTER   TYPE = "application/vnd.cups-raster"
+	GCODE        TYPE = "text/x.gcode"
+	KICAD        TYPE = "application/x-kicad-pcb"
+	GERBER       TYPE = "application/vnd.gerber"
+	VERILOG      TYPE = "text/x-verilog"
+	VHDL         TYPE = "text/x-vhdl"
+	SPICE        TYPE = "text/x-spice"
+	OPENSCAD     TYPE = "application/x-openscad"
+	FIDOATTEST   TYPE = "application/x-fido-attestation+cbor"
+	CONFLUENT    TYPE = "application/vnd.confluent.wire"
+	OPENMETRICS  TYPE = "application/openmetrics-text"
+	INFLUXLINE   TYPE = "text/x-influx-line"
+	GRAPHITE     TYPE = "text/x-graphite"
+	PKPASS       TYPE = "application/vnd.apple.pkpass"
+	SKETCH       TYPE = "application/x-sketch"
+	FIGMA        TYPE = "application/x-figma"
+	ADOBEXD      TYPE = "application/x-adobe-xd"
+	INDESIGN     TYPE = "application/x-indesign"
+	AFFINITY     TYPE = "application/x-affinity"
+	PROCREATE    TYPE = "application/x-procreate"
+	CLIPSTUDIO   TYPE = "application/x-clip-studio"
+	KRA          TYPE = "application/x-krita"
+	ORA          TYPE = "image/openraster"
+	ADOBEASE     TYPE = "application/x-adobe-ase"
+	ADOBEACO     TYPE = "application/x-adobe-aco"
+	GIMPPALETTE  TYPE = "text/x-gimp-palette"
+	EXR          TYPE = "image/x-exr"
+	MAYAASCII    TYPE = "application/x-maya-ascii"
+	MAYABINARY   TYPE = "application/x-maya-binary"
+	HOUDINI      TYPE = "application/x-houdini"
+	NUKE         TYPE = "application/x-nuke"
+	FCPXML       TYPE = "application/vnd.apple.fcpxml+xml"
+	PGS          TYPE = "application/x-pgs"
+	VOBSUBIDX    TYPE = "text/x-vobsub-idx"
+	EDL          TYPE = "text/x-edl"
+	AAF          TYPE = "application/x-aaf"
+	PBZX         TYPE = "application/x-apple-pbzx"
+	BOM          TYPE = "application/x-apple-bom"
+	CHROMETRACE  TYPE = "application/x-chrome-trace+json"
+	FOLDEDSTACKS TYPE = "text/x-folded-stacks"
+	VPK          TYPE = "application/x-valve-vpk"
+	BSP          TYPE = "application/x-quake-bsp"
+	QUAKEPAK     TYPE = "application/x-quake-pak"
+	DOOMWAD      TYPE = "application/x-doom-wad"
+	VTF          TYPE = "image/x-vtf"
+	VMT          TYPE = "text/x-vmt"
+	NBT          TYPE = "application/x-minecraft-nbt"
+	AZW3         TYPE = "application/x-mobi8-ebook"
+	PALMPDB      TYPE = "application/vnd.palm"
+	PALMDOC      TYPE = "application/vnd.palm.doc"
+	PLUCKER      TYPE = "application/vnd.palm.plucker"
+	EREADER      TYPE = "application/vnd.palm.ereader"
+	FB3          TYPE = "application/x-zip-compressed-fb3"
+	CWL          TYPE = "application/x-cwl"
+	NEXTFLOW     TYPE = "text/x-nextflow"
+	SNAKEMAKE    TYPE = "text/x-snakemake"
+	ZEPPELIN     TYPE = "application/x-zeppelin-notebook+json"
+	DBC          TYPE = "application/x-databricks-archive"
+	ODFTEMPLATE  TYPE = "application/vnd.oasis.opendocument.formula-template"
+	OTC          TYPE = "application/vnd.oasis.opendocument.chart-template"
+	ODI          TYPE = "application/vnd.oasis.opendocument.image"
+	OTI          TYPE = "application/vnd.oasis.opendocument.image-template"
+	ODM          TYPE = "application/vnd.oasis.opendocument.text-master"
+	OTM          TYPE = "application/vnd.oasis.opendocument.text-master-template"
+	OTH          TYPE = "application/vnd.oasis.opendocument.text-web"
+	ODB          TYPE = "application/vnd.oasis.opendocument.database"
+	DOTX         TYPE = "application/vnd.openxmlformats-officedocument.wordprocessingml.template"
+	XLTX         TYPE = "application/vnd.openxmlformats-officedocument.spreadsheetml.template"
+	POTX         TYPE = "application/vnd.openxmlformats-officedocument.presentationml.template"
+	PPSX         TYPE = "application/vnd.openxmlformats-officedocument.presentationml.slideshow"
+	ASAR         TYPE = "application/x-asar"
+	NODESEA      TYPE = "application/x-node-sea"
+	DENO         TYPE = "application/x-deno-compiled"
 )